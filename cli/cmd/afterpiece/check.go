@@ -15,6 +15,10 @@ import (
 var (
 	codegenDebug    bool
 	checkParseTests bool
+	checkPackages   []string
+	checkWatch      bool
+	checkProgress   bool
+	checkFile       string
 )
 
 var checkCmd = &cobra.Command{
@@ -32,6 +36,10 @@ func init() {
 	rootCmd.AddCommand(checkCmd)
 	checkCmd.Flags().BoolVar(&codegenDebug, "codegen-debug", false, "Dump generated code (for debugging Encore's code generation)")
 	checkCmd.Flags().BoolVar(&checkParseTests, "tests", false, "Parse tests as well")
+	checkCmd.Flags().StringSliceVar(&checkPackages, "packages", nil, "Only report errors for the given import paths and the packages that depend on them")
+	checkCmd.Flags().BoolVarP(&checkWatch, "watch", "w", false, "Re-check the application whenever it changes")
+	checkCmd.Flags().BoolVar(&checkProgress, "progress", false, "Report each stage of the check as it starts")
+	checkCmd.Flags().StringVar(&checkFile, "file", "", "Only report errors in the package containing the given file, type-checking it on its own when possible")
 }
 
 func runChecks(appRoot, relPath string) {
@@ -51,6 +59,10 @@ func runChecks(appRoot, relPath string) {
 		CodegenDebug: codegenDebug,
 		ParseTests:   checkParseTests,
 		Environ:      os.Environ(),
+		Packages:     checkPackages,
+		Watch:        checkWatch,
+		Progress:     checkProgress,
+		SingleFile:   checkFile,
 	})
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "fatal: ", err)
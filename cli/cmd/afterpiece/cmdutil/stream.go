@@ -107,6 +107,9 @@ func StreamCommandOutput(stream CommandOutputStream, converter OutputConverter)
 		case *daemon.CommandMessage_Errors:
 			DisplayError(os.Stderr, m.Errors.Errinsrc)
 
+		case *daemon.CommandMessage_Progress:
+			_, _ = fmt.Fprintf(errWrite, "%s (%d%%)\n", m.Progress.Stage, m.Progress.Percent)
+
 		case *daemon.CommandMessage_Exit:
 			return int(m.Exit.Code)
 		}
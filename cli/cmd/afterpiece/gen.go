@@ -33,6 +33,12 @@ func init() {
 		excludedEndpointTags           []string
 		openAPIExcludePrivateEndpoints bool
 		tsSharedTypes                  bool
+		tsStrictOptionals              bool
+		tsEmitValidation               bool
+		styleIndent                    string
+		styleQuotes                    string
+		styleSemicolons                bool
+		dryRun                         bool
 		target                         string
 		tsDefaultClient                string
 	)
@@ -50,6 +56,7 @@ Supported language codes are:
   javascript: A JavaScript client using the Fetch API
   go: A Go client using net/http"
   openapi: An OpenAPI specification (EXPERIMENTAL)
+  postman: A Postman v2.1 collection
 
 By default all services with a non-private API endpoint are included.
 To further narrow down the services to generate, use the '--services' flag.
@@ -101,7 +108,7 @@ To further narrow down the services to generate, use the '--services' flag.
 			if lang == "" {
 				var ok bool
 				l, ok := clientgen.Detect(output)
-				if !ok {
+				if !ok && !dryRun {
 					fatal("could not detect language from output.\n\nNote: you can specify the language explicitly with --lang.")
 				}
 				lang = string(l)
@@ -109,7 +116,7 @@ To further narrow down the services to generate, use the '--services' flag.
 				// Validate the user input for the language
 				l, err := clientgen.GetLang(lang)
 				if err != nil {
-					fatal(fmt.Sprintf("%s: supported languages are `typescript`, `javascript`, `go` and `openapi`", err))
+					fatal(fmt.Sprintf("%s: supported languages are `typescript`, `javascript`, `go`, `openapi` and `postman`", err))
 				}
 				lang = string(l)
 			}
@@ -134,12 +141,18 @@ To further narrow down the services to generate, use the '--services' flag.
 				OpenapiExcludePrivateEndpoints: &openAPIExcludePrivateEndpoints,
 				TsSharedTypes:                  &tsSharedTypes,
 				TsClientTarget:                 &tsDefaultClient,
+				TsStrictOptionals:              &tsStrictOptionals,
+				TsEmitValidation:               &tsEmitValidation,
+				StyleIndent:                    &styleIndent,
+				StyleQuotes:                    &styleQuotes,
+				StyleSemicolons:                &styleSemicolons,
+				DryRun:                         &dryRun,
 			})
 			if err != nil {
 				fatal(err)
 			}
 
-			if output == "" {
+			if output == "" || dryRun {
 				_, _ = os.Stdout.Write(resp.Code)
 			} else {
 				if err := os.WriteFile(output, resp.Code, 0755); err != nil {
@@ -180,12 +193,13 @@ which may require the user-facing wrapper code to be manually generated.`,
 	genCmd.AddCommand(genClientCmd)
 	genCmd.AddCommand(genWrappersCmd)
 
-	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", and \"openapi\" are supported)")
+	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", \"openapi\", and \"postman\" are supported)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("lang", cmdutil.AutoCompleteFromStaticList(
 		"typescript\tA TypeScript client using the in-browser Fetch API",
 		"javascript\tA JavaScript client using the in-browser Fetch API",
 		"go\tA Go client using net/http",
 		"openapi\tAn OpenAPI specification",
+		"postman\tA Postman collection",
 	))
 
 	genClientCmd.Flags().StringVarP(&output, "output", "o", "", "The filename to write the generated client code to")
@@ -203,6 +217,18 @@ which may require the user-facing wrapper code to be manually generated.`,
 		BoolVar(&openAPIExcludePrivateEndpoints, "openapi-exclude-private-endpoints", false, "Exclude private endpoints from the OpenAPI spec")
 	genClientCmd.Flags().
 		BoolVar(&tsSharedTypes, "ts:shared-types", false, "Import types from ~backend instead of re-generating them")
+	genClientCmd.Flags().
+		BoolVar(&tsStrictOptionals, "ts:strict-optionals", false, "Render optional fields as required properties that may be null")
+	genClientCmd.Flags().
+		BoolVar(&tsEmitValidation, "ts:emit-validation", false, "Check that required request fields are set before sending requests")
+	genClientCmd.Flags().
+		StringVar(&styleIndent, "style:indent", "", "Indentation of TypeScript and JavaScript clients: \"tab\" or a number of spaces (defaults to 4)")
+	genClientCmd.Flags().
+		StringVar(&styleQuotes, "style:quotes", "", "Quote style of strings in TypeScript and JavaScript clients: \"single\" or \"double\"")
+	genClientCmd.Flags().
+		BoolVar(&styleSemicolons, "style:semicolons", false, "Terminate statements in TypeScript and JavaScript clients with semicolons")
+	genClientCmd.Flags().
+		BoolVar(&dryRun, "dry-run", false, "List the namespaces, endpoints and types the client would contain, without generating it")
 	genClientCmd.Flags().StringVar(&target, "target", "", "An optional target for the client (\"leap\")")
 	_ = genClientCmd.RegisterFlagCompletionFunc("target", cmdutil.AutoCompleteFromStaticList(
 		"leap\tA TypeScript client for apps created with Leap (https://leap.new) ",
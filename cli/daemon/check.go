@@ -1,7 +1,16 @@
 package daemon
 
 import (
+	"context"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/bep/debounce"
+
+	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/pkg/watcher"
 	daemonpb "encr.dev/proto/afterpiece/daemon"
 )
 
@@ -17,13 +26,47 @@ func (s *Server) Check(req *daemonpb.CheckRequest, stream daemonpb.Daemon_CheckS
 		return nil
 	}
 
-	buildDir, err := s.mgr.Check(stream.Context(), run.CheckParams{
+	params := run.CheckParams{
 		App:          app,
 		WorkingDir:   req.WorkingDir,
 		CodegenDebug: req.CodegenDebug,
 		Environ:      req.Environ,
 		Tests:        req.ParseTests,
-	})
+		Packages:     req.Packages,
+	}
+	if f := req.SingleFile; f != "" {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(app.Root(), req.WorkingDir, f)
+		}
+		params.SingleFile = filepath.Clean(f)
+	}
+	if req.Progress {
+		params.Progress = checkProgress(stream.Context(), stream)
+	}
+
+	if req.Watch {
+		ctx := stream.Context()
+		err := watchCheck(ctx, app, func() {
+			_, err := s.mgr.Check(ctx, params)
+			if ctx.Err() != nil {
+				// The client has gone away, so there's no one to report to.
+				return
+			}
+			if errList := run.AsErrorList(err); errList != nil || err == nil {
+				// A nil list tells the client there are no longer any errors.
+				_ = errList.SendToStream(stream)
+			} else {
+				log.Error().Msg(err.Error())
+			}
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to watch app")
+			streamExit(stream, 1)
+		}
+		return nil
+	}
+
+	buildDir, err := s.mgr.Check(stream.Context(), params)
 
 	exitCode := 0
 	if err != nil {
@@ -37,3 +80,50 @@ func (s *Server) Check(req *daemonpb.CheckRequest, stream daemonpb.Daemon_CheckS
 	streamExit(stream, exitCode)
 	return nil
 }
+
+// checkProgress returns a function reporting the start of each check stage
+// to stream, along with how far along the check is.
+// Nothing is sent once ctx is canceled.
+func checkProgress(ctx context.Context, stream commandStream) func(run.CheckStage) {
+	return func(stage run.CheckStage) {
+		if ctx.Err() != nil {
+			return
+		}
+		percent := 0
+		if idx := slices.Index(run.CheckStages, stage); idx >= 0 {
+			percent = idx * 100 / len(run.CheckStages)
+		}
+		_ = stream.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Progress{
+			Progress: &daemonpb.CommandProgress{Stage: string(stage), Percent: int32(percent)},
+		}})
+	}
+}
+
+// watchCheck calls check once, and then again whenever the app changes,
+// until ctx is canceled. Changes are debounced and calls to check never overlap.
+// When it returns no check is running and no more checks will start.
+func watchCheck(ctx context.Context, app *apps.Instance, check func()) error {
+	deb := &regenerateCodeDebouncer{
+		debounce: debounce.New(100 * time.Millisecond),
+		doRun: func() {
+			if ctx.Err() == nil {
+				check()
+			}
+		},
+	}
+	defer deb.Stop()
+
+	sub, err := app.Watch(func(_ *apps.Instance, events []watcher.Event) {
+		if !run.IgnoreEvents(events) {
+			deb.ChangeEvent()
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer app.Unwatch(sub)
+
+	deb.ChangeEvent() // run the initial check
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/afterpiece/daemon"
+)
+
+func TestWatchCheck(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	file := filepath.Join(root, "svc.go")
+	c.Assert(os.WriteFile(file, []byte("package svc\n"), 0644), qt.IsNil)
+
+	app := apps.NewInstance(root, "test", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checks := make(chan struct{}, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchCheck(ctx, app, func() { checks <- struct{}{} })
+	}()
+
+	waitForCheck := func(what string) {
+		select {
+		case <-checks:
+		case err := <-done:
+			c.Fatalf("watchCheck returned early: %v", err)
+		case <-time.After(5 * time.Second):
+			c.Fatalf("timed out waiting for %s", what)
+		}
+	}
+
+	waitForCheck("initial check")
+
+	// Changes to files that don't affect the app are ignored.
+	c.Assert(os.WriteFile(filepath.Join(root, "notes.txt"), []byte("hello"), 0644), qt.IsNil)
+	select {
+	case <-checks:
+		c.Fatal("unexpected check after irrelevant change")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	c.Assert(os.WriteFile(file, []byte("package svc\n\nvar X = 1\n"), 0644), qt.IsNil)
+	waitForCheck("check after change")
+
+	cancel()
+	select {
+	case err := <-done:
+		c.Assert(err, qt.IsNil)
+	case <-time.After(5 * time.Second):
+		c.Fatal("watchCheck did not return after cancellation")
+	}
+}
+
+func TestWatchCheckWaitsForInFlightCheck(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	c.Assert(os.WriteFile(filepath.Join(root, "svc.go"), []byte("package svc\n"), 0644), qt.IsNil)
+
+	app := apps.NewInstance(root, "test", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- watchCheck(ctx, app, func() {
+			close(started)
+			<-release
+		})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		c.Fatal("timed out waiting for initial check")
+	}
+
+	// Canceling must not return while the check is still running.
+	cancel()
+	select {
+	case <-done:
+		c.Fatal("watchCheck returned while a check was in flight")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-done:
+		c.Assert(err, qt.IsNil)
+	case <-time.After(5 * time.Second):
+		c.Fatal("watchCheck did not return after the check completed")
+	}
+}
+
+type recordingStream struct {
+	msgs []*daemonpb.CommandMessage
+}
+
+func (s *recordingStream) Send(msg *daemonpb.CommandMessage) error {
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+func TestCheckProgress(t *testing.T) {
+	c := qt.New(t)
+	stream := &recordingStream{}
+	progress := checkProgress(context.Background(), stream)
+	for _, stage := range run.CheckStages {
+		progress(stage)
+	}
+
+	var got []*daemonpb.CommandProgress
+	for _, msg := range stream.msgs {
+		got = append(got, msg.GetProgress())
+	}
+	c.Assert(got, qt.CmpEquals(protocmp.Transform()), []*daemonpb.CommandProgress{
+		{Stage: "parsing", Percent: 0},
+		{Stage: "validating config", Percent: 33},
+		{Stage: "compiling", Percent: 66},
+	})
+
+	// Nothing is sent once the stream's context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream = &recordingStream{}
+	checkProgress(ctx, stream)(run.CheckParsing)
+	c.Assert(stream.msgs, qt.HasLen, 0)
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -144,8 +145,13 @@ func (s *Server) GenClient(ctx context.Context, params *daemonpb.GenClientReques
 
 	lang := clientgen.Lang(params.Lang)
 
-	servicesToGenerate := clientgentypes.NewServiceSet(md, params.Services, params.ExcludedServices)
+	excludedServices := excludeInternalServices(md, params.Services, params.ExcludedServices)
+	servicesToGenerate := clientgentypes.NewServiceSet(md, params.Services, excludedServices)
 	tagSet := clientgentypes.NewTagSet(params.EndpointTags, params.ExcludedEndpointTags)
+	if params.GetDryRun() {
+		report := clientgen.DryRun(lang, md, servicesToGenerate, tagSet)
+		return &daemonpb.GenClientResponse{Code: []byte(report.String())}, nil
+	}
 	opts := clientgentypes.Options{}
 	if params.OpenapiExcludePrivateEndpoints != nil {
 		opts.OpenAPIExcludePrivateEndpoints = *params.OpenapiExcludePrivateEndpoints
@@ -156,6 +162,17 @@ func (s *Server) GenClient(ctx context.Context, params *daemonpb.GenClientReques
 	if params.TsClientTarget != nil {
 		opts.TSClientTarget = *params.TsClientTarget
 	}
+	if params.TsStrictOptionals != nil {
+		opts.TSStrictOptionals = *params.TsStrictOptionals
+	}
+	if params.TsEmitValidation != nil {
+		opts.TSEmitValidation = *params.TsEmitValidation
+	}
+	style, err := clientgentypes.ParseStyle(params.GetStyleIndent(), params.GetStyleQuotes(), params.GetStyleSemicolons())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.Style = style
 	code, err := clientgen.Client(lang, params.AppId, md, servicesToGenerate, tagSet, opts)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -163,6 +180,19 @@ func (s *Server) GenClient(ctx context.Context, params *daemonpb.GenClientReques
 	return &daemonpb.GenClientResponse{Code: code}, nil
 }
 
+// excludeInternalServices returns the services to exclude from a generated client.
+// Internal services are left out of generated clients unless they're requested
+// by name, so they're added to the given exclude list otherwise.
+func excludeInternalServices(md *meta.Data, include, exclude []string) []string {
+	exclude = slices.Clone(exclude)
+	for _, svc := range md.Svcs {
+		if svc.Internal && !slices.Contains(include, svc.Name) {
+			exclude = append(exclude, svc.Name)
+		}
+	}
+	return exclude
+}
+
 func (s *Server) SecretsRefresh(ctx context.Context, req *daemonpb.SecretsRefreshRequest) (*daemonpb.SecretsRefreshResponse, error) {
 	app, err := s.apps.Track(req.AppRoot)
 	if err != nil {
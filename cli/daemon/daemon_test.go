@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/clientgen/clientgentypes"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+)
+
+func TestExcludeInternalServices(t *testing.T) {
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "admin", Internal: true},
+			{Name: "public"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{
+			name:    "wildcard_excludes_internal",
+			include: []string{"*"},
+			want:    []string{"public"},
+		},
+		{
+			name:    "wildcard_with_explicit_internal",
+			include: []string{"*", "admin"},
+			want:    []string{"admin", "public"},
+		},
+		{
+			name:    "explicit_internal",
+			include: []string{"admin"},
+			want:    []string{"admin"},
+		},
+		{
+			name:    "exclude",
+			include: []string{"*"},
+			exclude: []string{"public"},
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			exclude := excludeInternalServices(md, tt.include, tt.exclude)
+			set := clientgentypes.NewServiceSet(md, tt.include, exclude)
+			c.Assert(set.List(), qt.DeepEquals, tt.want)
+		})
+	}
+}
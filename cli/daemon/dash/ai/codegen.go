@@ -92,6 +92,8 @@ func (s *Type) Render() string {
 			tags = fmt.Sprintf(" `query:\"%s\"`", f.WireName)
 		case apienc.Header:
 			tags = fmt.Sprintf(" `header:\"%s\"`", f.WireName)
+		case apienc.Path:
+			tags = fmt.Sprintf(" `path:\"%s\"`", f.WireName)
 		}
 		rtn.WriteString(fmt.Sprintf("  %s %s%s\n", f.Name, f.Type, tags))
 	}
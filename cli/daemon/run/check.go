@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"path/filepath"
 	"runtime"
 
 	"github.com/cockroachdb/errors"
@@ -33,6 +34,39 @@ type CheckParams struct {
 
 	// Tests specifies whether to parse and codegen for tests as well.
 	Tests bool
+
+	// Packages, if non-empty, limits the reported errors to the given import
+	// paths and the packages that depend on them. The whole app is still
+	// compiled, and errors elsewhere fail the check without being reported.
+	Packages []string
+
+	// SingleFile, if non-empty, is the absolute path to a file whose package to check.
+	// The package is first type-checked on its own, and its type errors are reported
+	// right away if its directives and declarations are unchanged since the last full
+	// check of the package. Otherwise the full check runs, reporting only the errors
+	// in the file's package. A successful check always runs the full check.
+	SingleFile string
+
+	// Progress, if non-nil, is called as each stage of the check starts.
+	Progress func(CheckStage)
+}
+
+// CheckStage is a stage of checking an app.
+type CheckStage string
+
+const (
+	CheckParsing          CheckStage = "parsing"
+	CheckValidatingConfig CheckStage = "validating config"
+	CheckCompiling        CheckStage = "compiling"
+)
+
+// CheckStages are the stages of a check, in the order they run.
+var CheckStages = []CheckStage{CheckParsing, CheckValidatingConfig, CheckCompiling}
+
+func (p *CheckParams) progress(stage CheckStage) {
+	if p.Progress != nil {
+		p.Progress(stage)
+	}
 }
 
 // Check checks the app for errors.
@@ -64,12 +98,37 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 
 	bld := builderimpl.Resolve(expSet)
 	defer fns.CloseIgnore(bld)
+
+	p.progress(CheckParsing)
+	if p.SingleFile != "" {
+		dir := filepath.Dir(p.SingleFile)
+		tc, err := bld.TypeCheck(ctx, builder.TypeCheckParams{
+			Build: buildInfo,
+			App:   p.App,
+			Dir:   dir,
+		})
+		if tc != nil {
+			if err != nil && mgr.sameShape(dir, tc.Shape) {
+				// Nothing affecting how the app is parsed has changed,
+				// so report the package's type errors without the full check.
+				return "", err
+			}
+			defer func() {
+				if ctx.Err() == nil {
+					mgr.setShape(dir, tc.Shape)
+				}
+			}()
+		}
+	}
+
 	parse, err := bld.Parse(ctx, builder.ParseParams{
 		Build:       buildInfo,
 		App:         p.App,
 		Experiments: expSet,
 		WorkingDir:  p.WorkingDir,
 		ParseTests:  p.Tests,
+		Packages:    p.Packages,
+		SingleFile:  p.SingleFile,
 	})
 	if err != nil {
 		return "", err
@@ -79,6 +138,7 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 	}
 
 	// Validate the service configs.
+	p.progress(CheckValidatingConfig)
 	_, err = bld.ServiceConfigs(ctx, builder.ServiceConfigsParams{
 		Parse: parse,
 		CueMeta: &cueutil.Meta{
@@ -93,6 +153,7 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 		return "", err
 	}
 
+	p.progress(CheckCompiling)
 	result, err := bld.Compile(ctx, builder.CompileParams{
 		Build:       buildInfo,
 		App:         p.App,
@@ -107,3 +168,21 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 	}
 	return buildDir, err
 }
+
+// sameShape reports whether shape is the shape of the package in dir
+// seen by its last full single-file check.
+func (mgr *Manager) sameShape(dir, shape string) bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return mgr.shapes[dir] == shape
+}
+
+// setShape records the shape of the package in dir seen by a full single-file check.
+func (mgr *Manager) setShape(dir, shape string) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.shapes == nil {
+		mgr.shapes = make(map[string]string)
+	}
+	mgr.shapes[dir] = shape
+}
@@ -33,6 +33,10 @@ type Manager struct {
 	listeners []EventListener
 	mu        sync.Mutex
 	runs      map[string]*Run // id -> run
+
+	// shapes are the package shapes seen by the last full check of a single file,
+	// keyed by the package directory.
+	shapes map[string]string
 }
 
 // EventListener is the interface for listening to events
@@ -62,6 +62,8 @@ type regenerateCodeDebouncer struct {
 	mu       sync.Mutex
 	running  bool
 	runAfter bool
+	stopped  bool
+	inFlight sync.WaitGroup // tracks the running loop, if any
 
 	doRun func()
 }
@@ -70,6 +72,12 @@ func (g *regenerateCodeDebouncer) ChangeEvent() {
 	g.debounce(func() {
 		g.mu.Lock()
 
+		// Once stopped, pending runs are dropped.
+		if g.stopped {
+			g.mu.Unlock()
+			return
+		}
+
 		// If we're already running, mark to run again when complete.
 		if g.running {
 			g.runAfter = true
@@ -79,8 +87,10 @@ func (g *regenerateCodeDebouncer) ChangeEvent() {
 
 		// Otherwise, keep re-running for as long as change events come in.
 		g.running = true
+		g.inFlight.Add(1)
+		defer g.inFlight.Done()
 		g.runAfter = true // to start us off, at least once.
-		for g.runAfter {
+		for g.runAfter && !g.stopped {
 			g.runAfter = false // reset for next time
 			g.mu.Unlock()
 			g.doRun() // actually run
@@ -94,6 +104,15 @@ func (g *regenerateCodeDebouncer) ChangeEvent() {
 	})
 }
 
+// Stop stops the debouncer and waits for any in-flight run to complete.
+// Change events that are pending or arrive later are ignored.
+func (g *regenerateCodeDebouncer) Stop() {
+	g.mu.Lock()
+	g.stopped = true
+	g.mu.Unlock()
+	g.inFlight.Wait()
+}
+
 func (s *Server) regenerateUserCode(ctx context.Context, app *apps.Instance) {
 	if err := s.genUserFacing(ctx, app); err != nil {
 		log.Error().Err(err).Str("app", app.PlatformOrLocalID()).Msg("failed to regenerate app")
@@ -260,8 +260,8 @@ export namespace echo {
 
         StructPtr: Data<number, number>
         StructSlice: Data<string, string>[]
-        StructMap: { [key: string]: Data<string, number> }
-        StructMapPtr: { [key: string]: Data<string, string> }
+        StructMap: Record<string, Data<string, number>>
+        StructMapPtr: Record<string, Data<string, string>>
         AnonStruct: {
             AnonBird: string
         }
@@ -24,6 +24,7 @@ const (
 	Query     ParameterLocation = "query"     // Parameter is placed in the query string
 	Body      ParameterLocation = "body"      // Parameter is placed in the body
 	Cookie    ParameterLocation = "cookie"    // Parameter is placed in cookies
+	Path      ParameterLocation = "path"      // Parameter is placed in the URL path
 )
 
 var (
@@ -47,6 +48,10 @@ var (
 		omitEmptyOption: "omitempty",
 		overrideDefault: true,
 	}
+	PathTag = tagDescription{
+		location:        Path,
+		overrideDefault: true,
+	}
 )
 
 // authTags is a description of tags used for auth
@@ -62,6 +67,7 @@ var requestTags = map[string]tagDescription{
 	"qs":     QsTag,
 	"header": HeaderTag,
 	"cookie": CookieTag,
+	"path":   PathTag,
 	"json":   JSONTag,
 }
 
@@ -180,17 +186,20 @@ type RequestEncoding struct {
 	QueryParameters  []*ParameterEncoding `json:"query_parameters"`
 	CookieParameters []*ParameterEncoding `json:"cookie_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
+	// PathParameters are fields populated from the URL path parameters.
+	// Clients pass these as part of the path rather than in the payload.
+	PathParameters []*ParameterEncoding `json:"path_parameters"`
 }
 
 // ParameterEncodingMap returns the parameter encodings as a map, keyed by SrcName.
 func (e *RequestEncoding) ParameterEncodingMap() map[string]*ParameterEncoding {
-	return toEncodingMap(srcNameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters)
+	return toEncodingMap(srcNameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters, e.PathParameters)
 }
 
 // ParameterEncodingMapByName returns the parameter encodings as a map, keyed by Name.
 // Conflicts result in an undefined encoding getting set.
 func (e *RequestEncoding) ParameterEncodingMapByName() map[string][]*ParameterEncoding {
-	return toEncodingMultiMap(nameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters)
+	return toEncodingMultiMap(nameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters, e.PathParameters)
 }
 
 // ParameterEncoding expresses how a parameter should be encoded on the wire
@@ -209,6 +218,8 @@ type ParameterEncoding struct {
 	Type *schema.Type `json:"type"`
 	// RawTag specifies the raw, unparsed struct tag for the field.
 	RawTag string `json:"raw_tag"`
+	// Example is the example value of the struct field, if any.
+	Example *schema.Literal `json:"example,omitempty"`
 	// WireFormat is the wire format of the parameter.
 	WireFormat string `json:"wire_format"`
 	// Optional indicates whether the field is optional.
@@ -307,6 +318,7 @@ func DescribeRPC(appMetaData *meta.Data, rpc *meta.RPC, options *Options) (*RPCE
 			BodyParameters:   defaultEncoding.BodyParameters,
 			QueryParameters:  defaultEncoding.QueryParameters,
 			CookieParameters: defaultEncoding.CookieParameters,
+			PathParameters:   defaultEncoding.PathParameters,
 		}
 	}
 
@@ -647,8 +659,8 @@ func DescribeRequest(appMetaData *meta.Data, requestSchema *schema.Type, options
 			}
 		}
 
-		if keys := keyDiff(fields, Query, Header, Body, Cookie); len(keys) > 0 {
-			return nil, errors.Newf("request must only contain Query, Body, Header and Cookie parameters. Found: %v", keys)
+		if keys := keyDiff(fields, Query, Header, Body, Cookie, Path); len(keys) > 0 {
+			return nil, errors.Newf("request must only contain Query, Body, Header, Cookie and Path parameters. Found: %v", keys)
 		}
 		reqs = append(reqs, &RequestEncoding{
 			HTTPMethods:      methods,
@@ -656,6 +668,7 @@ func DescribeRequest(appMetaData *meta.Data, requestSchema *schema.Type, options
 			HeaderParameters: fields[Header],
 			CookieParameters: fields[Cookie],
 			BodyParameters:   fields[Body],
+			PathParameters:   fields[Path],
 		})
 	}
 
@@ -682,13 +695,19 @@ func describeParams(lang meta.Lang, encodingHints *encodingHints, payload *schem
 	return paramByLocation, nil
 }
 
-// formatName formats a parameter name with the default formatting for the location (e.g. snakecase for query)
-func formatName(lang meta.Lang, location ParameterLocation, name string) string {
+// formatName formats a parameter name with the default formatting for the location
+// (e.g. the app's query string casing for query)
+func formatName(lang meta.Lang, location ParameterLocation, field *schema.Field) string {
 	if location == Query && lang == meta.Lang_GO {
-		return idents.Convert(name, idents.SnakeCase)
+		// The query string name is derived using the app's casing convention.
+		// Metadata predating it only uses snake case.
+		if field.QueryStringName != "" {
+			return field.QueryStringName
+		}
+		return idents.Convert(field.Name, idents.SnakeCase)
 	}
 
-	return name
+	return field.Name
 }
 
 // IgnoreField returns true if the field name is "-" is any of the valid request or response tags
@@ -712,7 +731,7 @@ func IgnoreField(field *schema.Field) bool {
 // It returns nil, nil if the field is not to be encoded.
 func describeParam(lang meta.Lang, encodingHints *encodingHints, field *schema.Field) (*ParameterEncoding, error) {
 	location := encodingHints.defaultLocation
-	name := formatName(lang, encodingHints.defaultLocation, field.Name)
+	name := formatName(lang, encodingHints.defaultLocation, field)
 	param := ParameterEncoding{
 		Name:       name,
 		OmitEmpty:  false,
@@ -720,6 +739,7 @@ func describeParam(lang meta.Lang, encodingHints *encodingHints, field *schema.F
 		Doc:        field.Doc,
 		Type:       field.Typ,
 		RawTag:     field.RawTag,
+		Example:    field.Example,
 		Optional:   field.Optional,
 		WireFormat: name,
 	}
@@ -20,6 +20,14 @@ const (
 	MigrationStrategyAtlas     = "atlas"
 )
 
+// Casing conventions for API.QueryStringCasing.
+const (
+	NameCasingSnake = "snake"
+	NameCasingCamel = "camel"
+	NameCasingKebab = "kebab"
+	NameCasingAsIs  = "as-is"
+)
+
 const (
 	LegacyAppConfig     = "encore.app"
 	LegacyJsonAppConfig = "encore.json"
@@ -77,6 +85,16 @@ type File struct {
 
 	// Migrations configures the database migration strategy.
 	Migrations Migrations `json:"migrations,omitzero" toml:"migrations,omitzero"`
+
+	// API configures how API requests and responses are encoded.
+	API API `json:"api,omitzero" toml:"api,omitzero"`
+}
+
+type API struct {
+	// QueryStringCasing is the casing convention used to derive the query string
+	// name of fields without an explicit query tag: one of "snake", "camel",
+	// "kebab" or "as-is". If empty it defaults to "snake".
+	QueryStringCasing string `json:"query_string_casing,omitempty" toml:"query_string_casing,omitempty"`
 }
 
 type Migrations struct {
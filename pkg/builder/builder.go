@@ -95,6 +95,15 @@ type ParseParams struct {
 	WorkingDir  string
 	ParseTests  bool
 
+	// Packages, if non-empty, limits the reported parse and compile errors
+	// to the given import paths and the packages that depend on them.
+	Packages []string
+
+	// SingleFile, if non-empty, is the absolute path to a file.
+	// It limits the reported parse errors to the package containing the file,
+	// if the file belongs to one of the app's packages.
+	SingleFile string
+
 	// Optional writer to redirect stderr to.
 	Stderr option.Option[io.Writer]
 }
@@ -274,8 +283,24 @@ type ServiceConfigsResult struct {
 	ConfigFiles fs.FS
 }
 
+type TypeCheckParams struct {
+	Build BuildInfo
+	App   *apps.Instance
+
+	// Dir is the absolute path to the directory of the package to type-check.
+	Dir string
+}
+
+type TypeCheckResult struct {
+	// Shape summarizes the package's directives and top-level declarations,
+	// the parts of the package that affect how the app is parsed.
+	// It only changes when one of them does.
+	Shape string
+}
+
 type Impl interface {
 	Parse(context.Context, ParseParams) (*ParseResult, error)
+	TypeCheck(context.Context, TypeCheckParams) (*TypeCheckResult, error)
 	Compile(context.Context, CompileParams) (*CompileResult, error)
 	TestSpec(context.Context, TestSpecParams) (*TestSpecResult, error)
 	RunTests(context.Context, RunTestsParams) error
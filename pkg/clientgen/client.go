@@ -10,6 +10,7 @@ import (
 
 	"encr.dev/pkg/clientgen/clientgentypes"
 	"encr.dev/pkg/clientgen/openapi"
+	"encr.dev/pkg/clientgen/postman"
 	"encr.dev/pkg/errinsrc/srcerrors"
 	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 )
@@ -24,6 +25,7 @@ const (
 	LangJavascript Lang = "javascript"
 	LangGo         Lang = "go"
 	LangOpenAPI    Lang = "openapi"
+	LangPostman    Lang = "postman"
 )
 
 type generator interface {
@@ -70,9 +72,9 @@ func Client(
 	switch lang {
 	case LangTypeScript:
 		if opts.TSSharedTypes && md.Language == meta.Lang_TYPESCRIPT {
-			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: true, clientTarget: opts.TSClientTarget}
+			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: true, clientTarget: opts.TSClientTarget, strictOptionals: opts.TSStrictOptionals, emitValidation: opts.TSEmitValidation}
 		} else {
-			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: false}
+			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: false, strictOptionals: opts.TSStrictOptionals, emitValidation: opts.TSEmitValidation}
 		}
 	case LangJavascript:
 		gen = &javascript{generatorVersion: javascriptGenLatestVersion}
@@ -80,6 +82,8 @@ func Client(
 		gen = &golang{generatorVersion: goGenLatestVersion}
 	case LangOpenAPI:
 		gen = openapi.New(openapi.LatestVersion)
+	case LangPostman:
+		gen = postman.New(postman.LatestVersion)
 	default:
 		return nil, ErrUnknownLang
 	}
@@ -97,6 +101,9 @@ func Client(
 	if err := gen.Generate(params); err != nil {
 		return nil, fmt.Errorf("genclient.Generate %s %s: %v", lang, appSlug, err)
 	}
+	if lang == LangTypeScript || lang == LangJavascript {
+		return restyle(buf.Bytes(), opts.Style), nil
+	}
 	return buf.Bytes(), nil
 }
 
@@ -111,6 +118,8 @@ func GetLang(lang string) (Lang, error) {
 		return LangGo, nil
 	case "openapi", "swagger", "oas":
 		return LangOpenAPI, nil
+	case "postman":
+		return LangPostman, nil
 	default:
 		return LangUnknown, ErrUnknownLang
 	}
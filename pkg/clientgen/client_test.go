@@ -14,6 +14,7 @@ import (
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/clientgen/clientgentypes"
 	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 	"encr.dev/v2/v2builder"
 )
 
@@ -67,6 +68,8 @@ func TestClientCodeGenerationFromGoApp(t *testing.T) {
 						language, ok := Detect(file.Name())
 						if strings.Contains(file.Name(), "openapi") {
 							language, ok = LangOpenAPI, true
+						} else if strings.Contains(file.Name(), "postman") {
+							language, ok = LangPostman, true
 						}
 						c.Assert(ok, qt.IsTrue, qt.Commentf("Unable to detect language type for %s", file.Name()))
 
@@ -89,3 +92,124 @@ func TestClientCodeGenerationFromGoApp(t *testing.T) {
 		})
 	}
 }
+
+func TestClientCodeGenerationWithOptions(t *testing.T) {
+	tests := []struct {
+		input  string
+		golden string
+		lang   Lang
+		opts   clientgentypes.Options
+	}{
+		{
+			input:  "input_optionals.go",
+			golden: "expected_optionals_strict_typescript.ts",
+			lang:   LangTypeScript,
+			opts:   clientgentypes.Options{TSStrictOptionals: true},
+		},
+		{
+			input:  "input_validation.go",
+			golden: "expected_validation_emit_typescript.ts",
+			lang:   LangTypeScript,
+			opts:   clientgentypes.Options{TSEmitValidation: true},
+		},
+		{
+			input:  "input.go",
+			golden: "expected_style_tabs_typescript.ts",
+			lang:   LangTypeScript,
+			opts: clientgentypes.Options{Style: clientgentypes.Style{
+				Indent:     "\t",
+				Quotes:     "single",
+				Semicolons: true,
+			}},
+		},
+		{
+			input:  "input.go",
+			golden: "expected_style_spaces_javascript.js",
+			lang:   LangJavascript,
+			opts: clientgentypes.Options{Style: clientgentypes.Style{
+				Indent:     "  ",
+				Quotes:     "double",
+				Semicolons: true,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSuffix(tt.golden, filepath.Ext(tt.golden)), func(t *testing.T) {
+			c := qt.New(t)
+			md := parseGoApp(c, tt.input)
+
+			generatedClient, err := Client(
+				tt.lang,
+				"app",
+				md,
+				clientgentypes.AllServices(md),
+				clientgentypes.TagSet{},
+				tt.opts,
+			)
+			c.Assert(err, qt.IsNil)
+
+			golden.TestAgainst(c, "goapp/"+tt.golden, string(generatedClient))
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	c := qt.New(t)
+	md := parseGoApp(c, "input_reserved.go")
+
+	report := DryRun(LangTypeScript, md, clientgentypes.AllServices(md), clientgentypes.TagSet{})
+	c.Assert(report.Namespaces, qt.DeepEquals, []*NamespaceReport{
+		{Name: "class", Endpoints: []string{"Get"}, Decls: []string{"Item"}},
+		{Name: "svc", Endpoints: []string{"Latest"}, Decls: []string{"Response"}},
+	})
+	c.Assert(report.Warnings, qt.DeepEquals, []string{
+		"service class is named after a reserved word, and is renamed to _class in TypeScript and JavaScript clients",
+	})
+
+	// Go clients don't rename services named after JavaScript reserved words.
+	report = DryRun(LangGo, md, clientgentypes.AllServices(md), clientgentypes.TagSet{})
+	c.Assert(report.Warnings, qt.HasLen, 0)
+
+	// Packages declaring types used by the included services
+	// get a namespace, even if they're excluded services.
+	report = DryRun(LangTypeScript, md, clientgentypes.NewServiceSet(md, []string{"svc"}, nil), clientgentypes.TagSet{})
+	c.Assert(report.String(), qt.Equals, `namespace class
+  type Item
+namespace svc
+  endpoint Latest
+  type Response
+`)
+}
+
+func TestUnsupportedMapKey(t *testing.T) {
+	c := qt.New(t)
+	md := parseGoApp(c, "unsupported_mapkey.txt")
+
+	// TypeScript object keys can't be booleans, so there's no type to render.
+	_, err := Client(LangTypeScript, "app", md, clientgentypes.AllServices(md), clientgentypes.TagSet{}, clientgentypes.Options{})
+	c.Assert(err, qt.ErrorMatches, `.*unsupported map key type boolean: TypeScript object keys must be strings or numbers`)
+
+	// Other clients don't have that restriction.
+	_, err = Client(LangGo, "app", md, clientgentypes.AllServices(md), clientgentypes.TagSet{}, clientgentypes.Options{})
+	c.Assert(err, qt.IsNil)
+}
+
+// parseGoApp parses the app in the given testdata/goapp txtar archive
+// and returns its metadata.
+func parseGoApp(c *qt.C, name string) *meta.Data {
+	ar, err := txtar.ParseFile(filepath.Join("testdata", "goapp", name))
+	c.Assert(err, qt.IsNil)
+
+	base := c.TempDir()
+	err = txtar.Write(ar, base)
+	c.Assert(err, qt.IsNil)
+
+	res, err := v2builder.New().Parse(context.Background(), builder.ParseParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        apps.NewInstance(base, "app", ""),
+		WorkingDir: ".",
+	})
+	c.Assert(err, qt.IsNil)
+	return res.Meta
+}
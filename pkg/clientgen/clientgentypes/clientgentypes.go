@@ -2,7 +2,10 @@ package clientgentypes
 
 import (
 	"bytes"
+	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 )
@@ -12,6 +15,63 @@ type Options struct {
 	OpenAPIExcludePrivateEndpoints bool
 	TSSharedTypes                  bool
 	TSClientTarget                 string
+
+	// TSStrictOptionals renders optional fields in the TypeScript client
+	// as required properties of type `T | null`, instead of as optional properties.
+	TSStrictOptionals bool
+
+	// TSEmitValidation generates code in the TypeScript client that checks
+	// that all required request fields are set before sending a request.
+	TSEmitValidation bool
+
+	// Style configures the formatting of the TypeScript and JavaScript clients.
+	Style Style
+}
+
+// Style configures the formatting of generated TypeScript and JavaScript code.
+// The zero value keeps the code as generated: indented with four spaces,
+// with each string quoted the way the generator happens to write it,
+// and without semicolons.
+type Style struct {
+	// Indent is the string to indent with, like "\t" or "  ".
+	// If empty, the code is indented with four spaces.
+	Indent string
+
+	// Quotes is the quote style for string literals: "single" or "double".
+	// If empty, the strings are quoted as generated.
+	Quotes string
+
+	// Semicolons terminates statements with semicolons.
+	Semicolons bool
+}
+
+// ParseStyle parses the style settings of the gen client command.
+// The indentation is either "tab" or a number of spaces,
+// and the quote style is either "single" or "double".
+// Empty settings keep the code as generated.
+func ParseStyle(indent, quotes string, semicolons bool) (Style, error) {
+	style := Style{Semicolons: semicolons}
+
+	switch indent {
+	case "":
+	case "tab":
+		style.Indent = "\t"
+	default:
+		n, err := strconv.Atoi(indent)
+		if err != nil || n < 1 || n > 8 {
+			return Style{}, fmt.Errorf("invalid indentation %q: must be \"tab\" or a number of spaces between 1 and 8", indent)
+		}
+		style.Indent = strings.Repeat(" ", n)
+	}
+
+	switch quotes {
+	case "", "single", "double":
+		style.Quotes = quotes
+	default:
+		return Style{}, fmt.Errorf("invalid quote style %q: must be \"single\" or \"double\"", quotes)
+	}
+
+	return style, nil
 }
 
 type GenerateParams struct {
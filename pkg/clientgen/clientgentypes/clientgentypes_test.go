@@ -0,0 +1,82 @@
+package clientgentypes
+
+import (
+	"slices"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+)
+
+func TestNewServiceSet(t *testing.T) {
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "admin", Internal: true},
+			{Name: "public"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{
+			name:    "wildcard",
+			include: []string{"*"},
+			want:    []string{"admin", "public"},
+		},
+		{
+			name:    "explicit",
+			include: []string{"admin"},
+			want:    []string{"admin"},
+		},
+		{
+			name:    "exclude",
+			include: []string{"*"},
+			exclude: []string{"public"},
+			want:    []string{"admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			set := NewServiceSet(md, tt.include, tt.exclude)
+			c.Assert(set.List(), qt.DeepEquals, tt.want)
+			for _, svc := range md.Svcs {
+				c.Assert(set.Has(svc.Name), qt.Equals, slices.Contains(tt.want, svc.Name))
+			}
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		indent, quotes string
+		want           Style
+		wantErr        bool
+	}{
+		{indent: "", quotes: "", want: Style{}},
+		{indent: "tab", quotes: "single", want: Style{Indent: "\t", Quotes: "single"}},
+		{indent: "2", quotes: "double", want: Style{Indent: "  ", Quotes: "double"}},
+		{indent: "0", wantErr: true},
+		{indent: "tabs", wantErr: true},
+		{quotes: "backtick", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.indent+"/"+tt.quotes, func(t *testing.T) {
+			c := qt.New(t)
+			got, err := ParseStyle(tt.indent, tt.quotes, false)
+			if tt.wantErr {
+				c.Assert(err, qt.IsNotNil)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(got, qt.Equals, tt.want)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package clientgen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"encr.dev/pkg/clientgen/clientgentypes"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+)
+
+// DryRunReport describes what a generated client would contain.
+type DryRunReport struct {
+	Namespaces []*NamespaceReport
+
+	// Warnings describe problems with the generated client,
+	// like type declarations whose names collide.
+	Warnings []string
+}
+
+// NamespaceReport describes a namespace in a generated client.
+// There's a namespace for every service with endpoints in the client,
+// and for every other package declaring types used by them.
+type NamespaceReport struct {
+	Name string
+
+	// Endpoints are the endpoints of the service, if the namespace is a service.
+	Endpoints []string
+
+	// Decls are the type declarations in the namespace.
+	Decls []string
+}
+
+// DryRun reports the namespaces, endpoints and type declarations
+// a client in the given language generated from the app metadata
+// would contain, without generating any code.
+//
+// The namespaces group the declarations by package, like the generators do,
+// while the warnings only cover problems affecting lang.
+// If lang is LangUnknown, the warnings for all languages are included.
+func DryRun(lang Lang, md *meta.Data, services clientgentypes.ServiceSet, tags clientgentypes.TagSet) *DryRunReport {
+	report := &DryRunReport{}
+	typs := getNamedTypes(md, services)
+
+	byName := make(map[string]*NamespaceReport)
+	namespace := func(name string) *NamespaceReport {
+		ns, ok := byName[name]
+		if !ok {
+			ns = &NamespaceReport{Name: name}
+			byName[name] = ns
+			report.Namespaces = append(report.Namespaces, ns)
+		}
+		return ns
+	}
+
+	// jsClient reports whether to warn about TypeScript and JavaScript clients.
+	jsClient := lang == LangUnknown || lang == LangTypeScript || lang == LangJavascript
+	for _, svc := range md.Svcs {
+		if !services.Has(svc.Name) || !hasPublicRPC(svc) {
+			continue
+		}
+		ns := namespace(svc.Name)
+		for _, rpc := range svc.Rpcs {
+			if rpc.AccessType != meta.RPC_PRIVATE && tags.IsRPCIncluded(rpc) {
+				ns.Endpoints = append(ns.Endpoints, rpc.Name)
+			}
+		}
+		if jsClient && isReservedWord(svc.Name) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf(
+				"service %s is named after a reserved word, and is renamed to _%s in TypeScript and JavaScript clients",
+				svc.Name, svc.Name))
+		}
+	}
+
+	for _, name := range typs.Namespaces() {
+		ns := namespace(name)
+
+		// Namespaces are named after packages, so types declared in
+		// different packages with the same name end up in the same namespace.
+		declaredIn := make(map[string]string) // decl name -> package path
+		for _, decl := range typs.Decls(name) {
+			if pkg, ok := declaredIn[decl.Name]; ok && pkg != decl.Loc.PkgPath {
+				report.Warnings = append(report.Warnings, fmt.Sprintf(
+					"type %s.%s is declared in both %s and %s, and only one of them can be generated",
+					name, decl.Name, pkg, decl.Loc.PkgPath))
+				continue
+			}
+			declaredIn[decl.Name] = decl.Loc.PkgPath
+			ns.Decls = append(ns.Decls, decl.Name)
+		}
+		slices.Sort(ns.Decls)
+	}
+
+	slices.SortFunc(report.Namespaces, func(a, b *NamespaceReport) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return report
+}
+
+// String formats the report for display.
+func (r *DryRunReport) String() string {
+	var b strings.Builder
+	for _, ns := range r.Namespaces {
+		fmt.Fprintf(&b, "namespace %s\n", ns.Name)
+		for _, ep := range ns.Endpoints {
+			fmt.Fprintf(&b, "  endpoint %s\n", ep)
+		}
+		for _, decl := range ns.Decls {
+			fmt.Fprintf(&b, "  type %s\n", decl)
+		}
+	}
+	for _, w := range r.Warnings {
+		fmt.Fprintf(&b, "warning: %s\n", w)
+	}
+	return b.String()
+}
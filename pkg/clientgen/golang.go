@@ -10,7 +10,6 @@ import (
 
 	"github.com/cockroachdb/errors"
 	. "github.com/dave/jennifer/jen"
-	"github.com/fatih/structtag"
 
 	"encr.dev/internal/gocodegen"
 	"encr.dev/internal/version"
@@ -344,13 +343,13 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 		}
 
 		// Add the documentation for the API to the interface method
-		if rpc.Doc != nil && !g.skipDocs {
+		if (rpc.Doc != nil || len(rpc.ParamDocs) > 0) && !g.skipDocs {
 			// Add a newline if this is not the first method
 			if len(interfaceMethods) > 0 {
 				interfaceMethods = append(interfaceMethods, Line())
 			}
 
-			for _, line := range strings.Split(strings.TrimSpace(*rpc.Doc), "\n") {
+			for _, line := range goDocLines(rpc) {
 				interfaceMethods = append(interfaceMethods, Comment(line))
 			}
 		}
@@ -380,8 +379,8 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 			continue
 		}
 
-		if rpc.Doc != nil && *rpc.Doc != "" && !g.skipDocs {
-			for _, line := range strings.Split(strings.TrimSpace(*rpc.Doc), "\n") {
+		if !g.skipDocs {
+			for _, line := range goDocLines(rpc) {
 				if line != "" {
 					file.Comment(line)
 				}
@@ -468,7 +467,12 @@ func (g *golang) rpcParams(rpc *meta.RPC) Code {
 		params = append(params, Id("request").Op("*").Qual("net/http", "Request"))
 	} else {
 		if rpc.RequestSchema != nil {
-			params = append(params, Id("params").Add(g.getType(rpc.RequestSchema)))
+			typ := g.getType(rpc.RequestSchema)
+			if rpc.RequestOptional {
+				// Optional requests are taken by pointer so callers can pass nil.
+				typ = Op("*").Add(typ)
+			}
+			params = append(params, Id("params").Add(typ))
 		}
 	}
 
@@ -576,6 +580,16 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
 
+		if rpc.RequestOptional {
+			code = append(code,
+				Comment("The request is optional; omitting it is the same as sending its zero value"),
+				If(Id("params").Op("==").Nil()).Block(
+					Id("params").Op("=").New(g.getType(rpc.RequestSchema)),
+				),
+				Line(),
+			)
+		}
+
 		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 {
 			code = append(code, Comment("Convert our params into the objects we need for the request"))
 		}
@@ -921,7 +935,7 @@ func (g *golang) getType(typ *schema.Type) Code {
 
 			// Add the field tags
 			if field.RawTag != "" {
-				tags, err := structtag.Parse(field.RawTag)
+				tags, err := parseRawTag(field.RawTag)
 				if err != nil {
 					panic("raw tags failed to parse") // This shouldn't happen at runtime, because the parser should have caught this
 				}
@@ -1056,8 +1070,9 @@ func (g *golang) generateTypeDefinitions(stmt FileStatement, decls []*schema.Dec
 			typ = typ.Types(types...)
 		}
 
-		// All types which are not structs should be aliases
-		if decl.Type.GetStruct() == nil && len(decl.TypeParams) == 0 {
+		// All types which are not structs should be aliases,
+		// as should anything declared as an alias.
+		if decl.Alias || (decl.Type.GetStruct() == nil && len(decl.TypeParams) == 0) {
 			typ = typ.Op("=")
 		}
 
@@ -1072,7 +1087,7 @@ func (g *golang) generateAnonStructTypes(fields []*encoding.ParameterEncoding, e
 		tagValue.WriteString(field.Name)
 
 		// Parse the tags and extract the encoding tag
-		tags, err := structtag.Parse(field.RawTag)
+		tags, err := parseRawTag(field.RawTag)
 		if err != nil {
 			return nil, errors.Wrapf(err, "parse tags: %s", field.SrcName)
 		}
@@ -184,14 +184,28 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 		js.WriteByte('\n')
 
 		// Doc string
-		if rpc.Doc != nil && *rpc.Doc != "" {
-			scanner := bufio.NewScanner(strings.NewReader(*rpc.Doc))
+		hasDoc := rpc.Doc != nil && *rpc.Doc != ""
+		params := jsDocParams(js.md, rpc)
+		if hasDoc || len(params) > 0 {
 			indent()
 			js.WriteString("/**\n")
-			for scanner.Scan() {
+			if hasDoc {
+				scanner := bufio.NewScanner(strings.NewReader(*rpc.Doc))
+				for scanner.Scan() {
+					indent()
+					js.WriteString(" * ")
+					js.WriteString(scanner.Text())
+					js.WriteByte('\n')
+				}
+				if len(params) > 0 {
+					indent()
+					js.WriteString(" *\n")
+				}
+			}
+			for _, line := range params {
 				indent()
 				js.WriteString(" * ")
-				js.WriteString(scanner.Text())
+				js.WriteString(line)
 				js.WriteByte('\n')
 			}
 			indent()
@@ -383,6 +397,25 @@ func (js *javascript) streamCallSite(w *indentWriter, rpc *meta.RPC, rpcPath str
 	return nil
 }
 
+// stringValues returns the expressions for encoding the given fields
+// as strings, for use in query strings and form-encoded bodies.
+func (js *javascript) stringValues(fields []*encoding.ParameterEncoding) map[string]string {
+	dict := make(map[string]string)
+	for _, field := range fields {
+		if list := field.Type.GetList(); list != nil {
+			dict[field.WireFormat] = js.Dot("params", field.SrcName) +
+				".map((v) => " + js.convertBuiltinToString(list.Elem.GetBuiltin(), "v", field.Optional) + ")"
+		} else {
+			dict[field.WireFormat] = js.convertBuiltinToString(
+				field.Type.GetBuiltin(),
+				js.Dot("params", field.SrcName),
+				field.Optional,
+			)
+		}
+	}
+	return dict
+}
+
 func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string) error {
 	// Work out how we're going to encode and call this RPC
 	rpcEncoding, err := encoding.DescribeRPC(js.md, rpc, &encoding.Options{SrcNameTag: "json"})
@@ -404,6 +437,13 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 	headers := ""
 	query := ""
 	body := ""
+	formEncoded := false
+
+	// An omitted optional request leaves all its parameters unset.
+	ifGiven := ""
+	if rpc.RequestOptional {
+		ifGiven = "params === undefined ? undefined : "
+	}
 
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
@@ -422,7 +462,7 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 				dict[field.WireFormat] = js.convertBuiltinToString(field.Type.GetBuiltin(), ref, field.Optional)
 			}
 
-			w.WriteString("const headers = makeRecord(")
+			w.WriteString("const headers = " + ifGiven + "makeRecord(")
 			js.Values(w, dict)
 			w.WriteString(")\n\n")
 		}
@@ -431,28 +471,25 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 		if len(reqEnc.QueryParameters) > 0 {
 			query = "query"
 
-			dict := make(map[string]string)
-			for _, field := range reqEnc.QueryParameters {
-				if list := field.Type.GetList(); list != nil {
-					dict[field.WireFormat] = js.Dot("params", field.SrcName) +
-						".map((v) => " + js.convertBuiltinToString(list.Elem.GetBuiltin(), "v", field.Optional) + ")"
-				} else {
-					dict[field.WireFormat] = js.convertBuiltinToString(
-						field.Type.GetBuiltin(),
-						js.Dot("params", field.SrcName),
-						field.Optional,
-					)
-				}
-			}
-
-			w.WriteString("const query = makeRecord(")
-			js.Values(w, dict)
+			w.WriteString("const query = " + ifGiven + "makeRecord(")
+			js.Values(w, js.stringValues(reqEnc.QueryParameters))
 			w.WriteString(")\n\n")
 		}
 
 		// Generate the body
 		if len(reqEnc.BodyParameters) > 0 {
-			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+			if rpc.BodyEncoding == meta.RPC_FORM {
+				// Form-encoded bodies are encoded just like query strings
+				formEncoded = true
+				body = "encodeQuery(form)"
+				if rpc.RequestOptional {
+					body = "form === undefined ? undefined : encodeQuery(form)"
+				}
+
+				w.WriteString("// Encode the body as form data\nconst form = " + ifGiven + "makeRecord(")
+				js.Values(w, js.stringValues(reqEnc.BodyParameters))
+				w.WriteString(")\n\n")
+			} else if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
 			} else {
@@ -465,16 +502,28 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 					dict[fieldName] = js.Dot("params", fieldName)
 				}
 
-				w.WriteString("// Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)\nconst body = ")
+				w.WriteString("// Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)\nconst body = " + ifGiven)
 				js.Values(w, dict)
 				w.WriteString("\n\n")
 			}
 		}
 	}
 
-	// Build the call to callTypedAPI
+	// Build the call to callTypedAPI, or to callAPI for form-encoded
+	// requests as callTypedAPI always sends the body as JSON.
+	callFn := "callTypedAPI"
+	if formEncoded {
+		callFn = "callAPI"
+		contentType := `"Content-Type": "application/x-www-form-urlencoded"`
+		if headers != "" {
+			headers = "headers: {..." + headers + ", " + contentType + "}"
+		} else {
+			headers = "headers: {" + contentType + "}"
+		}
+	}
 	callAPI := fmt.Sprintf(
-		"this.baseClient.callTypedAPI(\"%s\", `%s`",
+		"this.baseClient.%s(\"%s\", `%s`",
+		callFn,
 		rpcEncoding.DefaultMethod,
 		rpcPath,
 	)
@@ -1206,11 +1255,17 @@ func (js *javascript) Values(w *indentWriter, dict map[string]string) {
 }
 
 func (js *javascript) typeName(identifier string) string {
+	var name string
 	if js.generatorVersion < JsExperimental {
-		return identifier
+		name = identifier
 	} else {
-		return idents.Convert(identifier, idents.PascalCase)
+		name = idents.Convert(identifier, idents.PascalCase)
 	}
+	// Unlike member names, the names of the exported service objects can't be reserved words.
+	if isReservedWord(name) {
+		name = "_" + name
+	}
+	return name
 }
 
 func (js *javascript) memberName(identifier string) string {
@@ -0,0 +1,134 @@
+// Package jsonschema generates JSON Schema documents for Encore types.
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"encr.dev/pkg/clientgen/openapi"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// defsRefPrefix is the prefix of references to definitions in $defs.
+const defsRefPrefix = "#/$defs/"
+
+// Schema is a JSON Schema document, or a subschema within one.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Examples    []any              `json:"examples,omitempty"`
+
+	Type    string   `json:"type,omitempty"`
+	Format  string   `json:"format,omitempty"`
+	Enum    []any    `json:"enum,omitempty"`
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// FromDecl returns a JSON Schema document describing the declaration with the given id.
+//
+// Types are described the same way as in generated OpenAPI specs,
+// except that pointers are nullable. The document references the declaration
+// through $ref, and all named types reachable from it (including the declaration
+// itself) are emitted in $defs. This means recursive types are represented
+// as references to their own definition.
+func FromDecl(md *meta.Data, declID uint32) (doc []byte, err error) {
+	if int(declID) >= len(md.Decls) {
+		return nil, errors.Newf("unknown declaration id %d", declID)
+	}
+
+	schemas := openapi.NewSchemas(md, openapi.SchemaOptions{NullablePointers: true})
+	ref, err := schemas.Type(&schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: declID}}})
+	if err != nil {
+		return nil, err
+	}
+
+	root := convert(ref)
+	root.Schema = Draft
+	root.Defs = make(map[string]*Schema)
+	for name, s := range schemas.Components() {
+		root.Defs[name] = convert(s)
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// convert converts an OpenAPI schema to the equivalent JSON Schema.
+func convert(ref *openapi3.SchemaRef) *Schema {
+	if ref == nil {
+		return nil
+	}
+	if ref.Ref != "" {
+		return &Schema{Ref: defsRefPrefix + strings.TrimPrefix(ref.Ref, openapi.ComponentRefPrefix)}
+	}
+
+	v := ref.Value
+	s := &Schema{
+		Title:       strings.TrimSpace(v.Title),
+		Description: strings.TrimSpace(v.Description),
+		Type:        v.Type,
+		Format:      v.Format,
+		Enum:        v.Enum,
+		Minimum:     v.Min,
+		Maximum:     v.Max,
+		Required:    v.Required,
+		Items:       convert(v.Items),
+	}
+	if v.Example != nil {
+		s.Examples = []any{v.Example}
+	}
+	if len(v.Properties) > 0 {
+		s.Properties = make(map[string]*Schema, len(v.Properties))
+		for name, prop := range v.Properties {
+			s.Properties[name] = convert(prop)
+		}
+	}
+	if v.AdditionalProperties.Schema != nil {
+		s.AdditionalProperties = convert(v.AdditionalProperties.Schema)
+	}
+	for _, sub := range v.AnyOf {
+		s.AnyOf = append(s.AnyOf, convert(sub))
+	}
+	for _, sub := range v.AllOf {
+		s.AllOf = append(s.AllOf, convert(sub))
+	}
+
+	if !v.Nullable {
+		return s
+	}
+
+	// JSON Schema has no nullable keyword; accept either the schema or null.
+	// The docs stay on the outer schema so they describe the value as a whole.
+	nullable := &Schema{Title: s.Title, Description: s.Description}
+	s.Title, s.Description = "", ""
+	if s.isWrappedRef() {
+		s = s.AllOf[0]
+	}
+	nullable.AnyOf = []*Schema{s, {Type: "null"}}
+	return nullable
+}
+
+// isWrappedRef reports whether s consists of nothing but
+// an allOf wrapping a single reference.
+func (s *Schema) isWrappedRef() bool {
+	return len(s.AllOf) == 1 && s.AllOf[0].Ref != "" &&
+		s.Title == "" && s.Description == "" && s.Type == "" && s.Enum == nil &&
+		s.Properties == nil && s.Items == nil && s.AnyOf == nil
+}
@@ -0,0 +1,135 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+func TestFromDecl(t *testing.T) {
+	str := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING}}
+	i32 := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_INT32}}
+	named := func(id uint32) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id}}}
+	}
+	ptr := func(t *schema.Type) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: t}}}
+	}
+	structType := func(fields ...*schema.Field) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: fields}}}
+	}
+	loc := &schema.Loc{PkgName: "svc", PkgPath: "example.com/svc"}
+
+	md := &meta.Data{
+		Decls: []*schema.Decl{
+			{
+				Id:   0,
+				Name: "User",
+				Doc:  "User is a user.\n",
+				Type: structType(
+					&schema.Field{Name: "Name", JsonName: "name", Typ: str},
+					&schema.Field{Name: "Address", Typ: named(1), Doc: "Where they live.\n"},
+					&schema.Field{Name: "Internal", JsonName: "-", Typ: str},
+				),
+				Loc: loc,
+			},
+			{
+				Id:   1,
+				Name: "Address",
+				Type: structType(
+					&schema.Field{Name: "City", Typ: str, Optional: true},
+				),
+				Loc: loc,
+			},
+			{
+				Id:   2,
+				Name: "Node",
+				Type: structType(
+					&schema.Field{Name: "Value", Typ: i32},
+					&schema.Field{Name: "Next", Typ: ptr(named(2))},
+				),
+				Loc: loc,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		declID uint32
+		want   string
+	}{
+		{
+			name:   "nested_named_type",
+			declID: 0,
+			want: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$ref": "#/$defs/svc.User",
+  "$defs": {
+    "svc.Address": {
+      "type": "object",
+      "properties": {
+        "City": {"type": "string"}
+      }
+    },
+    "svc.User": {
+      "title": "User is a user.",
+      "type": "object",
+      "properties": {
+        "Address": {
+          "title": "Where they live.",
+          "allOf": [{"$ref": "#/$defs/svc.Address"}]
+        },
+        "name": {"type": "string"}
+      },
+      "required": ["name", "Address"]
+    }
+  }
+}`,
+		},
+		{
+			name:   "recursive_linked_list",
+			declID: 2,
+			want: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$ref": "#/$defs/svc.Node",
+  "$defs": {
+    "svc.Node": {
+      "type": "object",
+      "properties": {
+        "Next": {
+          "anyOf": [{"$ref": "#/$defs/svc.Node"}, {"type": "null"}]
+        },
+        "Value": {"type": "integer", "format": "int32", "minimum": -2147483648, "maximum": 2147483647}
+      },
+      "required": ["Value", "Next"]
+    }
+  }
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			got, err := FromDecl(md, tt.declID)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(got), qt.JSONEquals, jsonValue(c, tt.want))
+		})
+	}
+
+	t.Run("unknown_decl", func(t *testing.T) {
+		c := qt.New(t)
+		_, err := FromDecl(md, 10)
+		c.Assert(err, qt.ErrorMatches, "unknown declaration id 10")
+	})
+}
+
+func jsonValue(c *qt.C, s string) any {
+	var v any
+	c.Assert(json.Unmarshal([]byte(s), &v), qt.IsNil)
+	return v
+}
@@ -32,6 +32,9 @@ type Generator struct {
 	spec      *openapi3.T
 	md        *meta.Data
 	seenDecls map[string]uint32
+
+	// nullablePointers describes pointer types as nullable schemas.
+	nullablePointers bool
 }
 
 func New(version GenVersion) *Generator {
@@ -46,15 +49,7 @@ func (g *Generator) Version() int {
 }
 
 func (g *Generator) Generate(p clientgentypes.GenerateParams) (err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			if b, ok := r.(bailout); ok {
-				err = b.err
-			} else {
-				panic(r)
-			}
-		}
-	}()
+	defer catchBailout(&err)
 
 	g.md = p.Meta
 	g.spec = newSpec(p.AppSlug)
@@ -170,7 +165,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 	}
 
 	// Add header parameters
-	for _, param := range reqEnc.HeaderParameters {
+	for _, param := range withParamDocs(rpc, reqEnc.HeaderParameters) {
 		op.Parameters = append(op.Parameters, &openapi3.ParameterRef{
 			Value: &openapi3.Parameter{
 				Name:            param.WireFormat,
@@ -183,7 +178,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				Deprecated:      false,
 				Required:        !param.Optional,
 				Schema:          g.schemaType(param.Type),
-				Example:         nil,
+				Example:         LiteralValue(param.Example),
 				Examples:        nil,
 				Content:         nil,
 			},
@@ -191,7 +186,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 	}
 
 	// Add query parameters
-	for _, param := range reqEnc.QueryParameters {
+	for _, param := range withParamDocs(rpc, reqEnc.QueryParameters) {
 		op.Parameters = append(op.Parameters, &openapi3.ParameterRef{
 			Value: &openapi3.Parameter{
 				Name:            param.WireFormat,
@@ -204,7 +199,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				Deprecated:      false,
 				Required:        !param.Optional,
 				Schema:          g.schemaType(param.Type),
-				Example:         nil,
+				Example:         LiteralValue(param.Example),
 				Examples:        nil,
 				Content:         nil,
 			},
@@ -213,11 +208,16 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 
 	// Add request body
 	if len(reqEnc.BodyParameters) > 0 {
+		content := g.bodyContent(withParamDocs(rpc, reqEnc.BodyParameters))
+		if rpc.BodyEncoding == meta.RPC_FORM {
+			// Form-encoded endpoints accept the same fields as form data.
+			content["application/x-www-form-urlencoded"] = content["application/json"]
+		}
 		op.RequestBody = &openapi3.RequestBodyRef{
 			Value: &openapi3.RequestBody{
 				Description: "",
 				Required:    false,
-				Content:     g.bodyContent(reqEnc.BodyParameters),
+				Content:     content,
 			},
 		}
 	}
@@ -281,6 +281,32 @@ func rpcPath(rpc *meta.RPC) string {
 	return b.String()
 }
 
+// withParamDocs returns copies of the request parameters of rpc
+// with the "@param" docs of the endpoint added to their own docs.
+func withParamDocs(rpc *meta.RPC, params []*encoding.ParameterEncoding) []*encoding.ParameterEncoding {
+	if len(rpc.ParamDocs) == 0 {
+		return params
+	}
+	docs := make(map[string]string, len(rpc.ParamDocs))
+	for _, d := range rpc.ParamDocs {
+		docs[d.Name] = d.Doc
+	}
+
+	res := make([]*encoding.ParameterEncoding, len(params))
+	for i, p := range params {
+		if doc := docs[p.SrcName]; doc != "" {
+			cp := *p
+			if cp.Doc = strings.TrimSpace(p.Doc); cp.Doc != "" {
+				cp.Doc += "\n\n"
+			}
+			cp.Doc += doc
+			p = &cp
+		}
+		res[i] = p
+	}
+	return res
+}
+
 func splitDoc(doc string) (plaintextSummary, markdownDescription string) {
 	firstLine, remaining := doc, ""
 	if idx := strings.Index(doc, "\n"); idx >= 0 {
@@ -394,3 +420,15 @@ type bailout struct {
 func doBailout(err error) {
 	panic(bailout{err})
 }
+
+// catchBailout recovers from a bailout, storing its error in *err.
+// It must be called directly by a deferred statement.
+func catchBailout(err *error) {
+	if r := recover(); r != nil {
+		if b, ok := r.(bailout); ok {
+			*err = b.err
+		} else {
+			panic(r)
+		}
+	}
+}
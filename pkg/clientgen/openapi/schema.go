@@ -13,6 +13,49 @@ import (
 	schema "encr.dev/proto/afterpiece/parser/schema/v1"
 )
 
+// ComponentRefPrefix is the prefix of references to component schemas.
+const ComponentRefPrefix = "#/components/schemas/"
+
+// Schemas describes individual types from an app's metadata as OpenAPI schemas,
+// using the same mapping as the generated OpenAPI specs.
+//
+// Named types are described as references to component schemas.
+// The component schemas are collected as they're encountered,
+// and are available through Components.
+type Schemas struct {
+	g *Generator
+}
+
+// SchemaOptions configures how types are described by Schemas.
+type SchemaOptions struct {
+	// NullablePointers describes pointer types as nullable,
+	// instead of as their underlying type.
+	NullablePointers bool
+}
+
+// NewSchemas returns a Schemas describing the types in md.
+func NewSchemas(md *meta.Data, opts SchemaOptions) *Schemas {
+	g := New(LatestVersion)
+	g.md = md
+	g.spec = &openapi3.T{Components: &openapi3.Components{
+		Schemas: make(openapi3.Schemas),
+	}}
+	g.nullablePointers = opts.NullablePointers
+	return &Schemas{g: g}
+}
+
+// Type returns the schema for typ.
+func (s *Schemas) Type(typ *schema.Type) (ref *openapi3.SchemaRef, err error) {
+	defer catchBailout(&err)
+	return s.g.schemaType(typ), nil
+}
+
+// Components returns the component schemas of the named types
+// encountered so far, keyed by their name.
+func (s *Schemas) Components() openapi3.Schemas {
+	return s.g.spec.Components.Schemas
+}
+
 func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.Content {
 	if len(params) == 0 {
 		return nil
@@ -24,6 +67,7 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 		val := g.schemaType(p.Type)
 		if vv := val.Value; vv != nil {
 			vv.Title, vv.Description = splitDoc(p.Doc)
+			vv.Example = LiteralValue(p.Example)
 		}
 		props[p.WireFormat] = val
 		if !p.Optional {
@@ -71,6 +115,9 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 			if vv := val.Value; vv != nil {
 				// Direct schema - can set title and description directly
 				vv.Title, vv.Description = splitDoc(f.Doc)
+				if f.Example != nil {
+					vv.Example = LiteralValue(f.Example)
+				}
 			} else if val.Ref != "" && f.Doc != "" {
 				// Schema reference with field documentation - use allOf pattern to add description
 				// This is the recommended workaround for OpenAPI 3.0 to add descriptions to $ref
@@ -97,16 +144,19 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 		// TODO non-string keys are not supported
 		s := openapi3.NewObjectSchema()
 		s.AdditionalProperties = openapi3.AdditionalProperties{
-			Schema: g.schemaType(t.Map.Value),
+			Schema: g.elemSchemaType(t.Map.Value),
 		}
 		return s.NewRef()
 
 	case *schema.Type_List:
 		arr := openapi3.NewArraySchema()
-		arr.Items = g.schemaType(t.List.Elem)
+		arr.Items = g.elemSchemaType(t.List.Elem)
 		return arr.NewRef()
 
 	case *schema.Type_Pointer:
+		if g.nullablePointers {
+			return nullable(g.schemaType(t.Pointer.Base))
+		}
 		return g.schemaType(t.Pointer.Base)
 
 	case *schema.Type_Option:
@@ -218,6 +268,33 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 	}
 }
 
+// elemSchemaType is like schemaType but for list elements and map values.
+// Unlike struct fields, elements can't be omitted, so an optional element
+// is represented as a nullable schema.
+func (g *Generator) elemSchemaType(typ *schema.Type) *openapi3.SchemaRef {
+	opt, ok := typ.Typ.(*schema.Type_Option)
+	if !ok {
+		return g.schemaType(typ)
+	}
+
+	return nullable(g.schemaType(opt.Option.Value))
+}
+
+// nullable marks val as nullable.
+func nullable(val *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if val.Ref != "" {
+		// Schema references can't be made nullable directly, so wrap it.
+		return &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Nullable: true,
+				AllOf:    []*openapi3.SchemaRef{val},
+			},
+		}
+	}
+	val.Value.Nullable = true
+	return val
+}
+
 func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 	switch t {
 	case schema.Builtin_BOOL:
@@ -250,6 +327,8 @@ func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 		return openapi3.NewUUIDSchema()
 	case schema.Builtin_JSON:
 		return openapi3.NewObjectSchema()
+	case schema.Builtin_ANY:
+		return openapi3.NewSchema()
 	case schema.Builtin_USER_ID:
 		return openapi3.NewStringSchema()
 	case schema.Builtin_DECIMAL:
@@ -308,7 +387,7 @@ func (g *Generator) namedSchemaType(typ *schema.Named) *openapi3.SchemaRef {
 		}
 
 		return &openapi3.SchemaRef{
-			Ref: "#/components/schemas/" + candidate,
+			Ref: ComponentRefPrefix + candidate,
 		}
 	}
 }
@@ -431,3 +510,21 @@ func (g *Generator) pathParamType(typ meta.PathSegment_ParamType) *openapi3.Sche
 		panic("unreachable")
 	}
 }
+
+// LiteralValue returns the Go value of lit, for use in examples.
+// It returns nil if lit is nil.
+func LiteralValue(lit *schema.Literal) any {
+	switch v := lit.GetValue().(type) {
+	case *schema.Literal_Str:
+		return v.Str
+	case *schema.Literal_Boolean:
+		return v.Boolean
+	case *schema.Literal_Int:
+		return v.Int
+	case *schema.Literal_Uint:
+		return v.Uint
+	case *schema.Literal_Float:
+		return v.Float
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package postman
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/clientgen/openapi"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+// exampler generates example values for schema types.
+//
+// Types are described using the OpenAPI generator's schemas,
+// and the examples are derived from those schemas.
+type exampler struct {
+	schemas *openapi.Schemas
+
+	// inProgress tracks the component schemas currently being expanded,
+	// to stop recursive types from expanding forever.
+	inProgress map[string]bool
+}
+
+func newExampler(md *meta.Data) *exampler {
+	return &exampler{
+		schemas:    openapi.NewSchemas(md, openapi.SchemaOptions{}),
+		inProgress: make(map[string]bool),
+	}
+}
+
+// value returns an example value for typ, marshalable to JSON.
+func (e *exampler) value(typ *schema.Type) (any, error) {
+	ref, err := e.schemas.Type(typ)
+	if err != nil {
+		return nil, err
+	}
+	return e.schemaValue(ref), nil
+}
+
+// paramValue returns an example value for the parameter p,
+// using the example given for its field if there is one.
+func (e *exampler) paramValue(p *encoding.ParameterEncoding) (any, error) {
+	if v := openapi.LiteralValue(p.Example); v != nil {
+		return v, nil
+	}
+	return e.value(p.Type)
+}
+
+// stringParamValue returns an example value for a header, query string
+// or form-encoded parameter.
+func (e *exampler) stringParamValue(p *encoding.ParameterEncoding) (string, error) {
+	v, err := e.paramValue(p)
+	if err != nil {
+		return "", err
+	}
+	if list, ok := v.([]any); ok && len(list) > 0 {
+		v = list[0]
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func (e *exampler) schemaValue(ref *openapi3.SchemaRef) any {
+	if ref == nil {
+		return nil
+	}
+	if ref.Ref != "" {
+		name := strings.TrimPrefix(ref.Ref, openapi.ComponentRefPrefix)
+		if e.inProgress[name] {
+			return nil
+		}
+		e.inProgress[name] = true
+		defer delete(e.inProgress, name)
+		return e.schemaValue(e.schemas.Components()[name])
+	}
+
+	s := ref.Value
+	switch {
+	case s.Example != nil:
+		return s.Example
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case len(s.AllOf) > 0:
+		return e.schemaValue(s.AllOf[0])
+	case len(s.AnyOf) > 0:
+		return e.schemaValue(s.AnyOf[0])
+	case len(s.OneOf) > 0:
+		return e.schemaValue(s.OneOf[0])
+	}
+
+	switch s.Type {
+	case openapi3.TypeObject:
+		obj := object{}
+		for _, name := range propertyOrder(s) {
+			obj = append(obj, member{Key: name, Value: e.schemaValue(s.Properties[name])})
+		}
+		return obj
+	case openapi3.TypeArray:
+		return []any{e.schemaValue(s.Items)}
+	case openapi3.TypeBoolean:
+		return false
+	case openapi3.TypeInteger, openapi3.TypeNumber:
+		return 0
+	case openapi3.TypeString:
+		switch s.Format {
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		default:
+			// Strings, bytes (base64-encoded), decimals and user ids.
+			return ""
+		}
+	default:
+		return nil
+	}
+}
+
+// propertyOrder returns the property names of s in the order to write them.
+// Required properties come first, in the order they're declared,
+// followed by the optional ones in alphabetical order.
+func propertyOrder(s *openapi3.Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; ok {
+			names = append(names, name)
+		}
+	}
+	var optional []string
+	for name := range s.Properties {
+		if !slices.Contains(s.Required, name) {
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(optional)
+	return append(names, optional...)
+}
+
+// object is a JSON object that keeps the order of its members.
+type object []member
+
+type member struct {
+	Key   string
+	Value any
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
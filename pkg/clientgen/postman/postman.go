@@ -0,0 +1,273 @@
+// Package postman generates Postman collections describing an app's API.
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/clientgen/clientgentypes"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+)
+
+type GenVersion int
+
+const (
+	// Initial is the originally released Postman collection generator
+	Initial GenVersion = iota
+
+	// Experimental can be used to lock experimental or uncompleted features in the generated code
+	// It should always be the last item in the enum.
+	Experimental
+
+	LatestVersion GenVersion = Experimental - 1
+)
+
+// schemaURL is the JSON schema of the collection format we generate.
+const schemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// baseURLVar is the collection variable holding the base URL of the API.
+const baseURLVar = "baseUrl"
+
+type Generator struct {
+	ver GenVersion
+	md  *meta.Data
+}
+
+func New(version GenVersion) *Generator {
+	return &Generator{ver: version}
+}
+
+func (g *Generator) Version() int {
+	return int(g.ver)
+}
+
+func (g *Generator) Generate(p clientgentypes.GenerateParams) error {
+	g.md = p.Meta
+
+	coll := &collection{
+		Info: info{
+			Name:        p.AppSlug,
+			Description: "Generated by encore",
+			Schema:      schemaURL,
+		},
+		Variable: []keyValue{{Key: baseURLVar, Value: "http://localhost:4000"}},
+	}
+
+	for _, svc := range p.Meta.Svcs {
+		if !p.Services.Has(svc.Name) {
+			continue
+		}
+
+		folder := item{Name: svc.Name}
+		for _, rpc := range svc.Rpcs {
+			// Streaming endpoints can't be described as a single request,
+			// and private endpoints can't be called from outside the app.
+			if rpc.StreamingRequest || rpc.StreamingResponse || rpc.AccessType == meta.RPC_PRIVATE {
+				continue
+			}
+			if !p.Tags.IsRPCIncluded(rpc) {
+				continue
+			}
+
+			it, err := g.rpcItem(rpc)
+			if err != nil {
+				return err
+			}
+			folder.Item = append(folder.Item, it)
+		}
+
+		if len(folder.Item) > 0 {
+			coll.Item = append(coll.Item, folder)
+		}
+	}
+
+	out, err := json.MarshalIndent(coll, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal postman collection")
+	}
+	_, err = p.Buf.Write(out)
+	return err
+}
+
+func (g *Generator) rpcItem(rpc *meta.RPC) (item, error) {
+	enc, err := encoding.DescribeRPC(g.md, rpc, &encoding.Options{})
+	if err != nil {
+		return item{}, errors.Wrapf(err, "describe rpc %s.%s", rpc.ServiceName, rpc.Name)
+	}
+
+	req := &request{
+		Method:      enc.DefaultMethod,
+		URL:         g.url(rpc),
+		Description: description(rpc, enc.DefaultRequestEncoding),
+	}
+
+	if reqEnc := enc.DefaultRequestEncoding; reqEnc != nil {
+		ex := newExampler(g.md)
+		params := func(params []*encoding.ParameterEncoding) ([]keyValue, error) {
+			var kvs []keyValue
+			for _, p := range params {
+				v, err := ex.stringParamValue(p)
+				if err != nil {
+					return nil, errors.Wrapf(err, "example for parameter %s of rpc %s.%s", p.SrcName, rpc.ServiceName, rpc.Name)
+				}
+				kvs = append(kvs, keyValue{Key: p.WireFormat, Value: v})
+			}
+			return kvs, nil
+		}
+
+		if req.Header, err = params(reqEnc.HeaderParameters); err != nil {
+			return item{}, err
+		}
+		if req.URL.Query, err = params(reqEnc.QueryParameters); err != nil {
+			return item{}, err
+		}
+
+		if len(reqEnc.BodyParameters) > 0 {
+			if rpc.BodyEncoding == meta.RPC_FORM {
+				b := &body{Mode: "urlencoded"}
+				if b.URLEncoded, err = params(reqEnc.BodyParameters); err != nil {
+					return item{}, err
+				}
+				req.Body = b
+			} else {
+				var obj object
+				for _, p := range reqEnc.BodyParameters {
+					v, err := ex.paramValue(p)
+					if err != nil {
+						return item{}, errors.Wrapf(err, "example for field %s of rpc %s.%s", p.SrcName, rpc.ServiceName, rpc.Name)
+					}
+					obj = append(obj, member{Key: p.WireFormat, Value: v})
+				}
+				raw, err := json.MarshalIndent(obj, "", "  ")
+				if err != nil {
+					return item{}, errors.Wrapf(err, "marshal example body for rpc %s.%s", rpc.ServiceName, rpc.Name)
+				}
+				req.Header = append(req.Header, keyValue{Key: "Content-Type", Value: "application/json"})
+				req.Body = &body{
+					Mode:    "raw",
+					Raw:     string(raw),
+					Options: &bodyOptions{Raw: rawOptions{Language: "json"}},
+				}
+			}
+		}
+	}
+
+	req.URL.Raw = req.URL.raw()
+	return item{Name: rpc.Name, Request: req}, nil
+}
+
+// description returns the description of the request calling rpc,
+// listing the documented request fields by their wire names after the doc.
+func description(rpc *meta.RPC, reqEnc *encoding.RequestEncoding) string {
+	var b strings.Builder
+	if rpc.Doc != nil {
+		b.WriteString(strings.TrimSpace(*rpc.Doc))
+	}
+	if len(rpc.ParamDocs) == 0 {
+		return b.String()
+	}
+
+	var params map[string]*encoding.ParameterEncoding
+	if reqEnc != nil {
+		params = reqEnc.ParameterEncodingMap()
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Parameters:\n")
+	for _, p := range rpc.ParamDocs {
+		name := p.Name
+		if enc, ok := params[p.Name]; ok {
+			name = enc.WireFormat
+		}
+		b.WriteString("\n- `" + name + "`: " + strings.ReplaceAll(p.Doc, "\n", "\n  "))
+	}
+	return b.String()
+}
+
+// url returns the URL for calling rpc, with path parameters as Postman variables.
+func (g *Generator) url(rpc *meta.RPC) *url {
+	u := &url{Host: []string{"{{" + baseURLVar + "}}"}}
+	for _, seg := range rpc.Path.GetSegments() {
+		if seg.Type == meta.PathSegment_LITERAL {
+			u.Path = append(u.Path, seg.Value)
+			continue
+		}
+		u.Path = append(u.Path, ":"+seg.Value)
+		u.Variable = append(u.Variable, keyValue{Key: seg.Value, Value: ""})
+	}
+	return u
+}
+
+// The types below describe the subset of the Postman v2.1 collection format we generate.
+
+type collection struct {
+	Info     info       `json:"info"`
+	Item     []item     `json:"item"`
+	Variable []keyValue `json:"variable,omitempty"`
+}
+
+type info struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// item is either a folder of items, or a single request.
+type item struct {
+	Name    string   `json:"name"`
+	Item    []item   `json:"item,omitempty"`
+	Request *request `json:"request,omitempty"`
+}
+
+type request struct {
+	Method      string     `json:"method"`
+	Header      []keyValue `json:"header"`
+	URL         *url       `json:"url"`
+	Body        *body      `json:"body,omitempty"`
+	Description string     `json:"description,omitempty"`
+}
+
+type url struct {
+	Raw      string     `json:"raw"`
+	Host     []string   `json:"host"`
+	Path     []string   `json:"path"`
+	Query    []keyValue `json:"query,omitempty"`
+	Variable []keyValue `json:"variable,omitempty"`
+}
+
+func (u *url) raw() string {
+	s := strings.Join(u.Host, "") + "/" + strings.Join(u.Path, "/")
+	for i, q := range u.Query {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		s += fmt.Sprintf("%s%s=%s", sep, q.Key, q.Value)
+	}
+	return s
+}
+
+type body struct {
+	Mode       string       `json:"mode"`
+	Raw        string       `json:"raw,omitempty"`
+	URLEncoded []keyValue   `json:"urlencoded,omitempty"`
+	Options    *bodyOptions `json:"options,omitempty"`
+}
+
+type bodyOptions struct {
+	Raw rawOptions `json:"raw"`
+}
+
+type rawOptions struct {
+	Language string `json:"language"`
+}
+
+type keyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,442 @@
+package clientgen
+
+import (
+	"bytes"
+	"strings"
+
+	"encr.dev/pkg/clientgen/clientgentypes"
+)
+
+// generatedIndent is the indentation the TypeScript and JavaScript generators write.
+const generatedIndent = "    "
+
+// restyle reformats generated TypeScript or JavaScript code according to style.
+//
+// Rather than threading the style through every emitter, restyle makes a single
+// pass over the generated code. It tracks just enough of the lexical structure
+// to leave the contents of strings, template literals and comments untouched,
+// and to tell where a statement ends.
+func restyle(src []byte, style clientgentypes.Style) []byte {
+	if style == (clientgentypes.Style{}) {
+		return src
+	}
+	r := &restyler{src: src, style: style}
+	r.run()
+	return r.out.Bytes()
+}
+
+type bracketKind int
+
+const (
+	paren        bracketKind = iota
+	square                   // [ ... ]
+	block                    // a block of statements or declarations
+	members                  // an interface body or type literal
+	object                   // an object literal or enum body
+	templateExpr             // a ${ ... } substitution in a template literal
+)
+
+type bracket struct {
+	kind bracketKind
+	// controlHead reports whether the bracket is the head of
+	// an if, for, while or switch statement.
+	controlHead bool
+	// interfaceBody reports whether the bracket is the body of an interface.
+	interfaceBody bool
+}
+
+type tokenKind int
+
+const (
+	tokNone tokenKind = iota
+	tokWord           // identifier or keyword
+	tokNumber
+	tokLiteral // string, template or regexp literal
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// closed is the bracket closed by a ")", "]" or "}" token.
+	closed bracket
+}
+
+type restyler struct {
+	src   []byte
+	style clientgentypes.Style
+	pos   int
+	out   bytes.Buffer
+
+	brackets []bracket
+	// last and prev are the last two tokens, ignoring whitespace and comments.
+	last, prev token
+	// decl is "type" or "interface" within a type alias or interface declaration.
+	decl string
+
+	// lineHasTokens reports whether a token ended on the current line,
+	// and lastEnd is the offset in out just after it.
+	lineHasTokens bool
+	lastEnd       int
+}
+
+func (r *restyler) run() {
+	r.indent()
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		switch {
+		case c == '\n':
+			r.endLine()
+			r.out.WriteByte(c)
+			r.pos++
+			r.indent()
+		case c == ' ' || c == '\t' || c == '\r':
+			r.out.WriteByte(c)
+			r.pos++
+		case r.hasPrefix("//"):
+			end := bytes.IndexByte(r.src[r.pos:], '\n')
+			if end < 0 {
+				end = len(r.src) - r.pos
+			}
+			r.out.Write(r.src[r.pos : r.pos+end])
+			r.pos += end
+		case r.hasPrefix("/*"):
+			r.blockComment()
+		case c == '"' || c == '\'':
+			r.string()
+		case c == '`':
+			r.out.WriteByte(c)
+			r.pos++
+			r.templateRest()
+		case c == '/' && r.regexpAllowed():
+			r.regexp()
+		case isWordChar(c):
+			start := r.pos
+			for r.pos < len(r.src) && isWordChar(r.src[r.pos]) {
+				r.pos++
+			}
+			kind := tokWord
+			if c >= '0' && c <= '9' {
+				kind = tokNumber
+			}
+			r.out.Write(r.src[start:r.pos])
+			r.word(token{kind: kind, text: string(r.src[start:r.pos])})
+		default:
+			r.punct()
+		}
+	}
+	r.endLine()
+}
+
+func (r *restyler) hasPrefix(s string) bool {
+	return bytes.HasPrefix(r.src[r.pos:], []byte(s))
+}
+
+// word adds a word token, keeping track of the declaration it starts.
+func (r *restyler) word(t token) {
+	if r.inBlock() && (!r.lineHasTokens || r.last.text == "export" || r.last.text == "declare") {
+		switch t.text {
+		case "type", "interface":
+			r.decl = t.text
+		case "export", "declare":
+		default:
+			r.decl = ""
+		}
+	}
+	r.addToken(t)
+}
+
+// inBlock reports whether the innermost bracket is a block, or there is none.
+func (r *restyler) inBlock() bool {
+	n := len(r.brackets)
+	return n == 0 || r.brackets[n-1].kind == block
+}
+
+func (r *restyler) addToken(t token) {
+	r.prev, r.last = r.last, t
+	r.lineHasTokens = true
+	r.lastEnd = r.out.Len()
+}
+
+// indent rewrites the indentation at the start of a line.
+func (r *restyler) indent() {
+	if r.style.Indent == "" {
+		return
+	}
+	n := 0
+	for r.pos+n < len(r.src) && r.src[r.pos+n] == ' ' {
+		n++
+	}
+	r.out.WriteString(strings.Repeat(r.style.Indent, n/len(generatedIndent)))
+	r.out.WriteString(strings.Repeat(" ", n%len(generatedIndent)))
+	r.pos += n
+}
+
+func (r *restyler) blockComment() {
+	end := bytes.Index(r.src[r.pos+2:], []byte("*/"))
+	if end < 0 {
+		end = len(r.src)
+	} else {
+		end += r.pos + 4
+	}
+	for r.pos < end {
+		c := r.src[r.pos]
+		r.out.WriteByte(c)
+		r.pos++
+		if c == '\n' {
+			r.indent()
+		}
+	}
+}
+
+// string writes a string literal, converting it to the configured quote style.
+func (r *restyler) string() {
+	orig := r.src[r.pos]
+	quote := orig
+	switch r.style.Quotes {
+	case "single":
+		quote = '\''
+	case "double":
+		quote = '"'
+	}
+
+	r.out.WriteByte(quote)
+	r.pos++
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		switch {
+		case c == '\\' && r.pos+1 < len(r.src):
+			// The other quote doesn't need escaping.
+			if esc := r.src[r.pos+1]; esc != quote && (esc == '"' || esc == '\'') {
+				r.out.WriteByte(esc)
+			} else {
+				r.out.Write(r.src[r.pos : r.pos+2])
+			}
+			r.pos += 2
+			continue
+		case c == orig:
+			r.out.WriteByte(quote)
+			r.pos++
+			r.addToken(token{kind: tokLiteral})
+			return
+		case c == quote:
+			r.out.WriteByte('\\')
+		case c == '\n':
+			// Unterminated string; leave the rest of the line to the main loop.
+			r.addToken(token{kind: tokLiteral})
+			return
+		}
+		r.out.WriteByte(c)
+		r.pos++
+	}
+}
+
+// templateRest writes the rest of a template literal,
+// up to and including its end or the start of a substitution.
+func (r *restyler) templateRest() {
+	for r.pos < len(r.src) {
+		switch {
+		case r.src[r.pos] == '\\' && r.pos+1 < len(r.src):
+			r.out.Write(r.src[r.pos : r.pos+2])
+			r.pos += 2
+		case r.src[r.pos] == '`':
+			r.out.WriteByte('`')
+			r.pos++
+			r.addToken(token{kind: tokLiteral})
+			return
+		case r.hasPrefix("${"):
+			r.out.WriteString("${")
+			r.pos += 2
+			r.brackets = append(r.brackets, bracket{kind: templateExpr})
+			r.addToken(token{kind: tokPunct, text: "${"})
+			return
+		default:
+			r.out.WriteByte(r.src[r.pos])
+			r.pos++
+		}
+	}
+}
+
+// regexpAllowed reports whether a "/" starts a regular expression
+// rather than being a division operator.
+func (r *restyler) regexpAllowed() bool {
+	switch r.last.kind {
+	case tokNumber, tokLiteral:
+		return false
+	case tokWord:
+		return r.last.text == "return" || r.last.text == "typeof" || r.last.text == "case"
+	case tokPunct:
+		return r.last.text != ")" && r.last.text != "]" && r.last.text != "}"
+	}
+	return true
+}
+
+func (r *restyler) regexp() {
+	start := r.pos
+	r.pos++
+	inClass := false
+	for r.pos < len(r.src) && r.src[r.pos] != '\n' {
+		c := r.src[r.pos]
+		r.pos++
+		if c == '\\' {
+			r.pos++
+		} else if c == '[' {
+			inClass = true
+		} else if c == ']' {
+			inClass = false
+		} else if c == '/' && !inClass {
+			break
+		}
+	}
+	for r.pos < len(r.src) && isWordChar(r.src[r.pos]) {
+		r.pos++ // flags
+	}
+	r.out.Write(r.src[start:r.pos])
+	r.addToken(token{kind: tokLiteral})
+}
+
+func (r *restyler) punct() {
+	text := string(r.src[r.pos])
+	for _, op := range []string{"=>", "++", "--"} {
+		if r.hasPrefix(op) {
+			text = op
+			break
+		}
+	}
+	r.out.WriteString(text)
+	r.pos += len(text)
+	tok := token{kind: tokPunct, text: text}
+
+	switch text {
+	case "(":
+		head := r.last.kind == tokWord && controlKeywords[r.last.text]
+		r.brackets = append(r.brackets, bracket{kind: paren, controlHead: head})
+	case "[":
+		r.brackets = append(r.brackets, bracket{kind: square})
+	case "{":
+		kind := r.braceKind()
+		interfaceBody := r.decl == "interface" && r.inBlock()
+		if interfaceBody {
+			kind = members
+		}
+		r.brackets = append(r.brackets, bracket{kind: kind, interfaceBody: interfaceBody})
+	case ")", "]", "}":
+		if n := len(r.brackets); n > 0 {
+			tok.closed = r.brackets[n-1]
+			r.brackets = r.brackets[:n-1]
+		}
+		if tok.closed.kind == templateExpr {
+			r.templateRest()
+			return
+		}
+	}
+	r.addToken(tok)
+}
+
+// braceKind reports what kind of bracket a "{" opens.
+func (r *restyler) braceKind() bracketKind {
+	if n := len(r.brackets); r.decl == "type" || n > 0 && r.brackets[n-1].kind == members {
+		return members
+	}
+
+	switch r.last.kind {
+	case tokNone:
+		return block
+	case tokWord:
+		if objectKeywords[r.last.text] || r.prev.text == "enum" {
+			return object
+		}
+		return block
+	case tokPunct:
+		switch r.last.text {
+		case ")", "=>", ">", ";", "}":
+			return block
+		}
+	}
+	return object
+}
+
+// endLine terminates the statement ending the current line with a semicolon,
+// if the style calls for it.
+func (r *restyler) endLine() {
+	if r.style.Semicolons && r.lineHasTokens && r.endsStatement() {
+		tail := bytes.Clone(r.out.Bytes()[r.lastEnd:])
+		r.out.Truncate(r.lastEnd)
+		r.out.WriteByte(';')
+		r.out.Write(tail)
+	}
+	r.lineHasTokens = false
+}
+
+// endsStatement reports whether the last token on the current line ends a statement.
+func (r *restyler) endsStatement() bool {
+	// Only statements directly within a block or at the top level,
+	// and members of interfaces and type literals, are terminated.
+	if n := len(r.brackets); n > 0 && r.brackets[n-1].kind != block && r.brackets[n-1].kind != members {
+		return false
+	}
+
+	switch r.last.kind {
+	case tokWord:
+		if continuingWords[r.last.text] {
+			return false
+		}
+	case tokPunct:
+		switch r.last.text {
+		case ")":
+			if r.last.closed.controlHead {
+				return false
+			}
+		case "}":
+			if k := r.last.closed.kind; k != object && k != members || r.last.closed.interfaceBody {
+				return false
+			}
+		case "]", ">", "++", "--":
+		default:
+			return false
+		}
+	}
+
+	// The statement continues if the next line starts with something
+	// that can't start a statement.
+	rest := bytes.TrimLeft(r.src[r.pos:], " \t\r\n")
+	if len(rest) == 0 || bytes.HasPrefix(rest, []byte("//")) || bytes.HasPrefix(rest, []byte("/*")) {
+		return true
+	}
+	if strings.IndexByte(".?:|&+-*/%=,([`<>^", rest[0]) >= 0 {
+		return false
+	}
+	end := 0
+	for end < len(rest) && isWordChar(rest[end]) {
+		end++
+	}
+	return !continuedByWords[string(rest[:end])]
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// controlKeywords are the keywords followed by a parenthesized statement head.
+var controlKeywords = map[string]bool{"if": true, "for": true, "while": true, "switch": true, "with": true}
+
+// objectKeywords are the keywords that can be followed by an object literal.
+var objectKeywords = map[string]bool{
+	"return": true, "import": true, "export": true, "default": true, "typeof": true, "in": true, "of": true,
+	"case": true, "yield": true, "await": true, "throw": true, "new": true,
+}
+
+// continuingWords are the keywords that can't end a statement.
+var continuingWords = map[string]bool{
+	"abstract": true, "as": true, "async": true, "await": true, "case": true, "class": true, "const": true,
+	"declare": true, "do": true, "else": true, "enum": true, "export": true, "extends": true, "finally": true,
+	"function": true, "implements": true, "import": true, "in": true, "instanceof": true, "interface": true,
+	"keyof": true, "let": true, "namespace": true, "new": true, "of": true, "private": true, "protected": true,
+	"public": true, "readonly": true, "static": true, "try": true, "type": true, "typeof": true, "var": true,
+}
+
+// continuedByWords are the keywords that continue the statement on the previous line.
+var continuedByWords = map[string]bool{
+	"as": true, "catch": true, "else": true, "extends": true, "finally": true, "implements": true,
+	"in": true, "instanceof": true, "satisfies": true,
+}
@@ -0,0 +1,153 @@
+package clientgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// TestStyleGoldens checks that the restyled clients are the generated clients
+// with only the formatting changed, and that the JavaScript one still parses.
+func TestStyleGoldens(t *testing.T) {
+	tests := []struct {
+		styled, unstyled string
+	}{
+		{"expected_style_tabs_typescript.ts", "expected_typescript.ts"},
+		{"expected_style_spaces_javascript.js", "expected_javascript.js"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSuffix(tt.styled, filepath.Ext(tt.styled)), func(t *testing.T) {
+			c := qt.New(t)
+			styledPath := filepath.Join("testdata", "goapp", tt.styled)
+			styled, err := os.ReadFile(styledPath)
+			c.Assert(err, qt.IsNil)
+			unstyled, err := os.ReadFile(filepath.Join("testdata", "goapp", tt.unstyled))
+			c.Assert(err, qt.IsNil)
+
+			got, want := styleTokens(string(styled)), styleTokens(string(unstyled))
+			for i := 0; i < min(len(got), len(want)); i++ {
+				if got[i] != want[i] {
+					c.Fatalf("token %d differs: got %q, want %q (after %q)", i, got[i], want[i], got[max(0, i-5):i])
+				}
+			}
+			c.Assert(len(got), qt.Equals, len(want))
+
+			if filepath.Ext(tt.styled) != ".js" {
+				// Parsing TypeScript needs the TypeScript compiler,
+				// so only the token comparison above applies.
+				return
+			}
+			node, err := exec.LookPath("node")
+			if err != nil {
+				c.Skip("node not found")
+			}
+			out, err := exec.Command(node, "--check", styledPath).CombinedOutput()
+			c.Assert(err, qt.IsNil, qt.Commentf("node --check: %s", out))
+		})
+	}
+}
+
+// styleTokens splits JavaScript or TypeScript source into the tokens that
+// must survive restyling. Whitespace, comments and semicolons are dropped,
+// and string literals are normalized so that the quote style doesn't matter.
+func styleTokens(src string) []string {
+	var (
+		toks []string
+		// templates holds, for each template substitution being
+		// tokenized, the number of braces opened within it.
+		templates []int
+	)
+
+	// template reads a template literal chunk starting at i,
+	// and returns the offset following it.
+	template := func(i int) int {
+		start := i
+		for i < len(src) {
+			switch {
+			case src[i] == '\\':
+				i += 2
+				continue
+			case src[i] == '`':
+				toks = append(toks, "tpl:"+src[start:i])
+				return i + 1
+			case strings.HasPrefix(src[i:], "${"):
+				toks = append(toks, "tpl:"+src[start:i])
+				templates = append(templates, 0)
+				return i + 2
+			}
+			i++
+		}
+		return i
+	}
+
+	for i := 0; i < len(src); {
+		ch := src[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ';':
+			i++
+
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return toks
+			}
+			i += end + 4
+
+		case ch == '"' || ch == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != ch {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			s := src[i+1 : min(j, len(src))]
+			s = strings.NewReplacer(`\'`, `'`, `\"`, `"`).Replace(s)
+			toks = append(toks, "str:"+s)
+			i = j + 1
+
+		case ch == '`':
+			i = template(i + 1)
+
+		case ch == '{' && len(templates) > 0:
+			templates[len(templates)-1]++
+			toks = append(toks, "{")
+			i++
+		case ch == '}' && len(templates) > 0:
+			if n := &templates[len(templates)-1]; *n > 0 {
+				*n--
+				toks = append(toks, "}")
+				i++
+			} else {
+				templates = templates[:len(templates)-1]
+				i = template(i + 1)
+			}
+
+		case isWordByte(ch):
+			j := i
+			for j < len(src) && isWordByte(src[j]) {
+				j++
+			}
+			toks = append(toks, src[i:j])
+			i = j
+
+		default:
+			toks = append(toks, src[i:i+1])
+			i++
+		}
+	}
+	return toks
+}
+
+func isWordByte(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= 0x80
+}
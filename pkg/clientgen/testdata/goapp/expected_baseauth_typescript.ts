@@ -93,6 +93,13 @@ export interface ClientOptions {
     /** Default RequestInit to be used for the client */
     requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
 
+    /**
+     * Interceptors are called in order before each API request is made,
+     * and can modify it, for example to add authentication headers.
+     * They are not called when connecting to streaming endpoints.
+     */
+    interceptors?: RequestInterceptor[]
+
     /**
      * Allows you to set the auth token to be used for each request
      * either by passing in a static token string or by passing in a function
@@ -346,13 +353,22 @@ export type AuthDataGenerator = () =>
 // A fetcher is the prototype for the inbuilt Fetch function
 export type Fetcher = typeof fetch;
 
-const boundFetch = fetch.bind(this);
+// InterceptedRequest is an API request about to be made.
+export interface InterceptedRequest {
+    url: string
+    init: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+}
+
+// A RequestInterceptor is called before an API request is made,
+// and returns the request to make instead.
+export type RequestInterceptor = (req: InterceptedRequest) => InterceptedRequest | Promise<InterceptedRequest>;
 
 class BaseClient {
     readonly baseURL: string
     readonly fetcher: Fetcher
     readonly headers: Record<string, string>
     readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+    readonly interceptors: RequestInterceptor[]
     readonly authGenerator?: AuthDataGenerator
 
     constructor(baseURL: string, options: ClientOptions) {
@@ -366,12 +382,15 @@ class BaseClient {
         }
 
         this.requestInit = options.requestInit ?? {};
+        this.interceptors = options.interceptors ?? [];
 
-        // Setup what fetch function we'll be using in the base client
+        // Setup what fetch function we'll be using in the base client.
+        // The global fetch is only looked up if no fetcher is given,
+        // so the client can be used in environments without one.
         if (options.fetcher !== undefined) {
             this.fetcher = options.fetcher
         } else {
-            this.fetcher = boundFetch
+            this.fetcher = fetch.bind(globalThis)
         }
 
         // Setup an authentication data generator using the auth data token option
@@ -507,9 +526,13 @@ class BaseClient {
             }
         }
 
-        // Make the actual request
+        // Let any interceptors modify the request, and then make it
         const queryString = query ? '?' + encodeQuery(query) : ''
-        const response = await this.fetcher(this.baseURL+path+queryString, init)
+        let req: InterceptedRequest = { url: this.baseURL+path+queryString, init }
+        for (const interceptor of this.interceptors) {
+            req = await interceptor(req)
+        }
+        const response = await this.fetcher(req.url, req.init)
 
         // handle any error responses
         if (!response.ok) {
@@ -0,0 +1,49 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import (
+    "context"
+    "time"
+)
+
+type Address struct {
+    Street string `json:"street"`
+    City   string `json:"city" example:"Stockholm"`
+}
+
+type Employee struct {
+    Name    string    `json:"name"`
+    Manager *Employee `json:"manager"`
+}
+
+type CreateUserParams struct {
+    RequestID string    `header:"X-Request-ID"`
+    DryRun    bool      `query:"dry_run" example:"true"`
+    Name      string    `json:"name"`
+    Age       int       `json:"age" example:"42"`
+    Emails    []string  `json:"emails"`
+    Address   *Address  `json:"address"`
+    Birthday  time.Time `json:"birthday"`
+    Manager   *Employee `json:"manager"`
+    Internal  string    `json:"-"`
+}
+
+type User struct {
+    ID string
+}
+
+// CreateUser creates a user in the given organization.
+//encore:api public method=POST path=/orgs/:org/users
+func CreateUser(ctx context.Context, org string, p *CreateUserParams) (*User, error) {
+    return nil, nil
+}
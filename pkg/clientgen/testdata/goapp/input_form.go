@@ -0,0 +1,41 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type SignupParams struct {
+    Email    string   `json:"email"`
+    Age      int      `json:"age"`
+    Tags     []string `json:"tags"`
+    Referrer string   `json:"referrer,omitempty"`
+    Locale   string   `header:"Accept-Language"`
+}
+
+type SignupResponse struct {
+    ID string
+}
+
+//encore:api public method=POST encoding=form
+func Signup(ctx context.Context, p *SignupParams) (*SignupResponse, error) {
+    return nil, nil
+}
+
+type LoginParams struct {
+    User     string `json:"user"`
+    Password string `json:"password"`
+}
+
+//encore:api public method=POST path=/login encoding=form
+func Login(ctx context.Context, p *LoginParams) error {
+    return nil
+}
@@ -0,0 +1,30 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+type Key string
+
+type Counts struct {
+    ByName  map[string]int
+    ByID    map[int]string
+    ByKey   map[Key]bool
+}
+
+-- svc/api.go --
+package svc
+
+import "context"
+
+//encore:api public
+func GetCounts(ctx context.Context) (*Counts, error) {
+    return nil, nil
+}
@@ -0,0 +1,38 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type Params struct {
+    Name string
+}
+
+type Filter struct {
+    Limit int    `query:"limit"`
+    Trace string `header:"X-Trace"`
+    Name  string
+}
+
+type Response struct {
+    Message string
+}
+
+//encore:api public method=POST optional
+func Create(ctx context.Context, p *Params) error {
+    return nil
+}
+
+//encore:api public method=POST optional
+func Search(ctx context.Context, p *Filter) (*Response, error) {
+    return nil, nil
+}
@@ -0,0 +1,50 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "encore.dev/types/option"
+
+type Profile struct {
+    Name     string
+    Nickname string `encore:"optional"`
+    Bio      option.Option[string]
+    Age      *int
+    Manager  *Profile
+    Scores   []option.Option[int]
+    Labels   map[string]option.Option[string]
+}
+
+type SearchParams struct {
+    Query string `query:"q" encore:"optional"`
+    Limit int    `query:"limit" encore:"optional"`
+    Trace string `header:"X-Trace" encore:"optional"`
+    Page  int    `header:"X-Page" encore:"optional"`
+}
+
+-- svc/api.go --
+package svc
+
+import (
+    "context"
+)
+
+// GetProfile returns a profile.
+//encore:api public
+func GetProfile(ctx context.Context) (*Profile, error) {
+    return nil, nil
+}
+
+// Search searches for profiles.
+//encore:api public method=GET
+func Search(ctx context.Context, p *SearchParams) error {
+    return nil
+}
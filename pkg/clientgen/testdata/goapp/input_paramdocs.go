@@ -0,0 +1,31 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type Params struct {
+    Name  string `json:"name"`
+    Count int
+}
+
+// Greet greets someone, as many times as requested.
+//
+// It's a dummy endpoint.
+//
+// @param Name the name of the person to greet
+// @param Count how many times to greet them.
+//   Defaults to once if zero.
+//encore:api public
+func Greet(ctx context.Context, p *Params) error {
+    return nil
+}
@@ -0,0 +1,36 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type GetPostParams struct {
+    UserID string `path:"id"`
+    PostID int    `path:"postID"`
+    Fields []string `query:"fields"`
+}
+
+type Post struct {
+    Title string
+}
+
+// GetPost returns a post by a user.
+//encore:api public method=GET path=/users/:id/posts/:postID
+func GetPost(ctx context.Context, id string, postID int, p *GetPostParams) (*Post, error) {
+    return nil, nil
+}
+
+// DeletePost deletes a post, without binding the path parameters to fields.
+//encore:api public method=DELETE path=/posts/:id
+func DeletePost(ctx context.Context, id int) error {
+    return nil
+}
@@ -0,0 +1,41 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- class/class.go --
+package class
+
+import "context"
+
+type Item struct {
+    Name string
+}
+
+//encore:api public method=GET path=/items/:id
+func Get(ctx context.Context, id string) (*Item, error) {
+    return nil, nil
+}
+
+-- svc/svc.go --
+package svc
+
+import (
+    "context"
+
+    "app/class"
+)
+
+type Response struct {
+    Item *class.Item
+}
+
+//encore:api public method=GET
+func Latest(ctx context.Context) (*Response, error) {
+    return nil, nil
+}
@@ -0,0 +1,39 @@
+-- go.mod --
+module app
+
+require (
+	encore.dev v1.52.1
+)
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+type CreateParams struct {
+    Name     string `json:"name"`
+    Nickname string `json:"nickname" encore:"optional"`
+    Address  *Address
+    Tags     []Tag
+    Limit    int `query:"limit"`
+}
+
+type Address struct {
+    Street string
+    City   string `encore:"optional"`
+}
+
+type Tag struct {
+    Label string
+}
+
+-- svc/api.go --
+package svc
+
+import "context"
+
+//encore:api public
+func Create(ctx context.Context, p *CreateParams) error {
+    return nil
+}
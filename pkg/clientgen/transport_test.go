@@ -0,0 +1,76 @@
+package clientgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/evanw/esbuild/pkg/api"
+	qt "github.com/frankban/quicktest"
+)
+
+// transportHarness calls an endpoint through the generated TypeScript client
+// with an injected fetcher and interceptors, and prints the calls they saw.
+const transportHarness = `
+import Client, { Local } from "./client.mjs";
+
+const calls = [];
+const client = new Client(Local, {
+    interceptors: [
+        (req) => {
+            calls.push("first " + req.url);
+            return { ...req, init: { ...req.init, headers: { ...req.init.headers, "X-First": "1" } } };
+        },
+        async (req) => {
+            calls.push("second " + req.init.headers["X-First"]);
+            return { ...req, url: req.url + "?second" };
+        },
+    ],
+    fetcher: async (url, init) => {
+        calls.push("fetch " + init.method + " " + url + " " + init.headers["X-First"]);
+        return new Response(null, { status: 200 });
+    },
+});
+await client.svc.FallbackPath("a", ["b", "c"]);
+console.log(JSON.stringify(calls));
+`
+
+// TestTypeScriptTransport checks that the generated TypeScript client
+// runs its interceptors in order and makes requests with the injected fetcher.
+func TestTypeScriptTransport(t *testing.T) {
+	c := qt.New(t)
+	node, err := exec.LookPath("node")
+	if err != nil {
+		c.Skip("node not found")
+	}
+
+	src, err := os.ReadFile(filepath.Join("testdata", "goapp", "expected_typescript.ts"))
+	c.Assert(err, qt.IsNil)
+	res := api.Transform(string(src), api.TransformOptions{
+		Loader: api.LoaderTS,
+		Format: api.FormatESModule,
+	})
+	c.Assert(res.Errors, qt.HasLen, 0)
+
+	dir := c.TempDir()
+	c.Assert(os.WriteFile(filepath.Join(dir, "client.mjs"), res.Code, 0644), qt.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "harness.mjs"), []byte(transportHarness), 0644), qt.IsNil)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(node, "harness.mjs")
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	c.Assert(err, qt.IsNil, qt.Commentf("node: %s", stderr.Bytes()))
+
+	var calls []string
+	c.Assert(json.Unmarshal(out, &calls), qt.IsNil, qt.Commentf("output: %s", out))
+	c.Assert(calls, qt.DeepEquals, []string{
+		"first http://localhost:4000/fallbackPath/a/b/c",
+		"second 1",
+		"fetch POST http://localhost:4000/fallbackPath/a/b/c?second 1",
+	})
+}
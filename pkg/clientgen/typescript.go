@@ -65,12 +65,17 @@ type typescript struct {
 	generatorVersion tsGenVersion
 	sharedTypes      bool
 	clientTarget     string
-
-	seenJSON           bool // true if a JSON type was seen
-	seenStream         bool // true if a stream endpoint was seen
-	seenHeaderResponse bool // true if we've seen a header used in a response object
-	hasAuth            bool // true if we've seen an authentication handler
-	authIsComplexType  bool // true if the auth type is a complex type
+	strictOptionals  bool // render optional fields as required, nullable properties
+	emitValidation   bool // validate required request fields before sending requests
+
+	validators         []uint32        // decl ids of the request validators to write
+	seenValidators     map[uint32]bool // decl ids in validators
+	pathBuilders       []*meta.RPC     // endpoints whose path is built from request fields
+	seenJSON           bool            // true if a JSON type was seen
+	seenStream         bool            // true if a stream endpoint was seen
+	seenHeaderResponse bool            // true if we've seen a header used in a response object
+	hasAuth            bool            // true if we've seen an authentication handler
+	authIsComplexType  bool            // true if the auth type is a complex type
 }
 
 func (ts *typescript) Version() int {
@@ -119,6 +124,8 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 		}
 	}
 	ts.writeExtraTypes()
+	ts.writeValidators()
+	ts.writePathBuilders()
 	ts.writeStreamClasses()
 	if err := ts.writeBaseClient(p.AppSlug); err != nil {
 		return err
@@ -299,14 +306,28 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 		ts.WriteByte('\n')
 
 		// Doc string
-		if rpc.Doc != nil && *rpc.Doc != "" {
-			scanner := bufio.NewScanner(strings.NewReader(*rpc.Doc))
+		hasDoc := rpc.Doc != nil && *rpc.Doc != ""
+		params := jsDocParams(ts.md, rpc)
+		if hasDoc || len(params) > 0 {
 			indent()
 			ts.WriteString("/**\n")
-			for scanner.Scan() {
+			if hasDoc {
+				scanner := bufio.NewScanner(strings.NewReader(*rpc.Doc))
+				for scanner.Scan() {
+					indent()
+					ts.WriteString(" * ")
+					ts.WriteString(scanner.Text())
+					ts.WriteByte('\n')
+				}
+				if len(params) > 0 {
+					indent()
+					ts.WriteString(" *\n")
+				}
+			}
+			for _, line := range params {
 				indent()
 				ts.WriteString(" * ")
-				ts.WriteString(scanner.Text())
+				ts.WriteString(line)
 				ts.WriteByte('\n')
 			}
 			indent()
@@ -337,9 +358,14 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 			ts.WriteString(payloadName + ": { ")
 		}
 		var rpcPath strings.Builder
+		pathFields := ts.pathFields(rpc)
 		for _, s := range rpc.Path.Segments {
 			rpcPath.WriteByte('/')
 			if s.Type != meta.PathSegment_LITERAL {
+				if pathFields != nil {
+					// The path is built from the request fields instead.
+					continue
+				}
 				if !ts.sharedTypes || inlinePathParams {
 					if nParams > 0 {
 						ts.WriteString(", ")
@@ -347,17 +373,7 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 
 					ts.WriteString(ts.nonReservedId(s.Value))
 					ts.WriteString(": ")
-					switch s.ValueType {
-					case meta.PathSegment_STRING, meta.PathSegment_UUID:
-						ts.WriteString("string")
-					case meta.PathSegment_BOOL:
-						ts.WriteString("boolean")
-					case meta.PathSegment_INT8, meta.PathSegment_INT16, meta.PathSegment_INT32, meta.PathSegment_INT64, meta.PathSegment_INT,
-						meta.PathSegment_UINT8, meta.PathSegment_UINT16, meta.PathSegment_UINT32, meta.PathSegment_UINT64, meta.PathSegment_UINT:
-						ts.WriteString("number")
-					default:
-						panic(fmt.Sprintf("unhandled PathSegment type %s", s.ValueType))
-					}
+					ts.WriteString(pathSegmentType(s))
 					if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
 						ts.WriteString("[]")
 					}
@@ -375,12 +391,20 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 		if inlinePathParams {
 			ts.WriteString(" }")
 		}
+		if pathFields != nil {
+			rpcPath.Reset()
+			rpcPath.WriteString("${" + ts.pathBuilderName(rpc) + "(params)}")
+		}
 
 		if (!isStream && rpc.RequestSchema != nil) || (isStream && hasHandshake) {
 			if !ts.sharedTypes && nParams > 0 {
 				ts.WriteString(", ")
 			}
-			ts.WriteString(payloadName + ": ")
+			ts.WriteString(payloadName)
+			if ts.optionalRequest(rpc) {
+				ts.WriteString("?")
+			}
+			ts.WriteString(": ")
 			if ts.sharedTypes {
 				fmt.Fprintf(ts, "RequestType<typeof %s>", rpcImportName(rpc))
 			} else if isStream {
@@ -584,6 +608,29 @@ func (ts *typescript) streamCallSite(w *indentWriter, rpc *meta.RPC, rpcPath str
 	return nil
 }
 
+// stringValues returns the expressions for encoding the given fields
+// as strings, for use in query strings and form-encoded bodies.
+func (ts *typescript) stringValues(fields []*encoding.ParameterEncoding) map[string]string {
+	dict := make(map[string]string)
+	for _, field := range fields {
+		if list := field.Type.GetList(); list != nil {
+			dot := ts.Dot("params", field.SrcName)
+			if field.Optional || ts.isRecursive(field.Type) {
+				dot += "?"
+			}
+			dict[field.WireFormat] = dot +
+				".map((v) => " + ts.convertBuiltinToString(list.Elem.GetBuiltin(), "v", field.Optional) + ")"
+		} else {
+			dict[field.WireFormat] = ts.convertBuiltinToString(
+				field.Type.GetBuiltin(),
+				ts.Dot("params", field.SrcName),
+				field.Optional,
+			)
+		}
+	}
+	return dict
+}
+
 func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpcPath string) error {
 	// Work out how we're going to encode and call this RPC
 	rpcEncoding, err := encoding.DescribeRPC(ts.md, rpc, &encoding.Options{SrcNameTag: "json"})
@@ -614,10 +661,28 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 		return nil
 	}
 
+	// An omitted optional request leaves all its parameters unset.
+	optional := ts.optionalRequest(rpc)
+	ifGiven := ""
+	if optional {
+		ifGiven = "params === undefined ? undefined : "
+	}
+
+	if ts.emitValidation && rpc.RequestSchema != nil {
+		if id, isList, ok := ts.validatedStruct(rpc.RequestSchema); ok && !isList {
+			if optional {
+				w.WriteStringf("if (params !== undefined) {\n    %s(params, \"params\")\n}\n\n", ts.validatorName(id))
+			} else {
+				w.WriteStringf("%s(params, \"params\")\n\n", ts.validatorName(id))
+			}
+		}
+	}
+
 	// Work out how we encode the Request Schema
 	headers := ""
 	query := ""
 	body := ""
+	formEncoded := false
 
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
@@ -636,7 +701,7 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 				dict[field.WireFormat] = ts.convertBuiltinToString(field.Type.GetBuiltin(), ref, field.Optional)
 			}
 
-			w.WriteString("const headers = makeRecord<string, string>(")
+			w.WriteString("const headers = " + ifGiven + "makeRecord<string, string>(")
 			ts.Values(w, dict)
 			w.WriteString(")\n\n")
 		}
@@ -645,32 +710,25 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 		if len(reqEnc.QueryParameters) > 0 {
 			query = "query"
 
-			dict := make(map[string]string)
-			for _, field := range reqEnc.QueryParameters {
-				if list := field.Type.GetList(); list != nil {
-					dot := ts.Dot("params", field.SrcName)
-					if field.Optional || ts.isRecursive(field.Type) {
-						dot += "?"
-					}
-					dict[field.WireFormat] = dot +
-						".map((v) => " + ts.convertBuiltinToString(list.Elem.GetBuiltin(), "v", field.Optional) + ")"
-				} else {
-					dict[field.WireFormat] = ts.convertBuiltinToString(
-						field.Type.GetBuiltin(),
-						ts.Dot("params", field.SrcName),
-						field.Optional,
-					)
-				}
-			}
-
-			w.WriteString("const query = makeRecord<string, string | string[]>(")
-			ts.Values(w, dict)
+			w.WriteString("const query = " + ifGiven + "makeRecord<string, string | string[]>(")
+			ts.Values(w, ts.stringValues(reqEnc.QueryParameters))
 			w.WriteString(")\n\n")
 		}
 
 		// Generate the body
 		if len(reqEnc.BodyParameters) > 0 {
-			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 && (!ts.sharedTypes || !hasPathParams(rpc)) {
+			if rpc.BodyEncoding == meta.RPC_FORM {
+				// Form-encoded bodies are encoded just like query strings
+				formEncoded = true
+				body = "encodeQuery(form)"
+				if optional {
+					body = "form === undefined ? undefined : encodeQuery(form)"
+				}
+
+				w.WriteString("// Encode the body as form data\nconst form = " + ifGiven + "makeRecord<string, string | string[]>(")
+				ts.Values(w, ts.stringValues(reqEnc.BodyParameters))
+				w.WriteString(")\n\n")
+			} else if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 && (!ts.sharedTypes || !hasPathParams(rpc)) {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
 			} else {
@@ -682,15 +740,30 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 					dict[field.WireFormat] = ts.Dot("params", field.SrcName)
 				}
 
-				w.WriteString("// Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)\nconst body: Record<string, any> = ")
+				w.WriteString("// Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)\n")
+				if optional {
+					w.WriteString("const body: Record<string, any> | undefined = " + ifGiven)
+				} else {
+					w.WriteString("const body: Record<string, any> = ")
+				}
 				ts.Values(w, dict)
 				w.WriteString("\n\n")
 			}
 		}
 	}
 
-	// Build the call to callTypedAPI
+	// Build the call to callTypedAPI, or to callAPI for form-encoded
+	// requests as callTypedAPI always sends the body as JSON.
 	callAPI := "this.baseClient.callTypedAPI("
+	if formEncoded {
+		callAPI = "this.baseClient.callAPI("
+		contentType := `"Content-Type": "application/x-www-form-urlencoded"`
+		if headers != "" {
+			headers = "headers: {..." + headers + ", " + contentType + "}"
+		} else {
+			headers = "headers: {" + contentType + "}"
+		}
+	}
 	if !ts.sharedTypes {
 		callAPI += fmt.Sprintf("\"%s\", ", rpcEncoding.DefaultMethod)
 	}
@@ -1196,6 +1269,13 @@ export interface ClientOptions {
 
     /** Default RequestInit to be used for the client */
     requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+
+    /**
+     * Interceptors are called in order before each API request is made,
+     * and can modify it, for example to add authentication headers.
+     * They are not called when connecting to streaming endpoints.
+     */
+    interceptors?: RequestInterceptor[]
 `)
 
 	if ts.hasAuth {
@@ -1265,13 +1345,22 @@ export type AuthDataGenerator = () =>
 // A fetcher is the prototype for the inbuilt Fetch function
 export type Fetcher = typeof fetch;
 
-const boundFetch = fetch.bind(this);
+// InterceptedRequest is an API request about to be made.
+export interface InterceptedRequest {
+    url: string
+    init: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+}
+
+// A RequestInterceptor is called before an API request is made,
+// and returns the request to make instead.
+export type RequestInterceptor = (req: InterceptedRequest) => InterceptedRequest | Promise<InterceptedRequest>;
 
 class BaseClient {
     readonly baseURL: string
     readonly fetcher: Fetcher
     readonly headers: Record<string, string>
-    readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }`)
+    readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+    readonly interceptors: RequestInterceptor[]`)
 
 	if ts.hasAuth {
 		ts.WriteString("\n    readonly authGenerator?: AuthDataGenerator")
@@ -1290,12 +1379,15 @@ class BaseClient {
         }
 
         this.requestInit = options.requestInit ?? {};
+        this.interceptors = options.interceptors ?? [];
 
-        // Setup what fetch function we'll be using in the base client
+        // Setup what fetch function we'll be using in the base client.
+        // The global fetch is only looked up if no fetcher is given,
+        // so the client can be used in environments without one.
         if (options.fetcher !== undefined) {
             this.fetcher = options.fetcher
         } else {
-            this.fetcher = boundFetch
+            this.fetcher = fetch.bind(globalThis)
         }`)
 
 	if ts.hasAuth {
@@ -1508,9 +1600,13 @@ class BaseClient {
             }
         }
 
-        // Make the actual request
+        // Let any interceptors modify the request, and then make it
         const queryString = query ? '?' + encodeQuery(query) : ''
-        const response = await this.fetcher(this.baseURL+path+queryString, init)
+        let req: InterceptedRequest = { url: this.baseURL+path+queryString, init }
+        for (const interceptor of this.interceptors) {
+            req = await interceptor(req)
+        }
+        const response = await this.fetcher(req.url, req.init)
 
         // handle any error responses
         if (!response.ok) {
@@ -1679,6 +1775,11 @@ func (ts *typescript) convertBuiltinToString(typ schema.Builtin, val string, isO
 	var code string
 	switch typ {
 	case schema.Builtin_STRING:
+		if isOptional && ts.strictOptionals {
+			// Strict optional fields may be null, which must be left out
+			// like undefined rather than sent as "null".
+			return fmt.Sprintf("%s ?? undefined", val)
+		}
 		return val
 	case schema.Builtin_JSON:
 		code = fmt.Sprintf("JSON.stringify(%s)", val)
@@ -1694,7 +1795,9 @@ func (ts *typescript) convertBuiltinToString(typ schema.Builtin, val string, isO
 		code = fmt.Sprintf("String(%s)", val)
 	}
 
-	if isOptional {
+	if isOptional && ts.strictOptionals {
+		code = fmt.Sprintf("%s == null ? undefined : %s", val, code)
+	} else if isOptional {
 		code = fmt.Sprintf("%s === undefined ? undefined : %s", val, code)
 	}
 	return code
@@ -1775,11 +1878,7 @@ func (ts *typescript) renderTyp(buf *bytes.Buffer, ns string, tt *schema.Type, n
 		buf.WriteString("[]")
 
 	case *schema.Type_Map:
-		buf.WriteString("{ [key: ")
-		ts.renderTyp(buf, ns, typ.Map.Key, numIndents)
-		buf.WriteString("]: ")
-		ts.renderTyp(buf, ns, typ.Map.Value, numIndents)
-		buf.WriteString(" }")
+		ts.renderMap(buf, ns, typ.Map, numIndents)
 
 	case *schema.Type_Builtin:
 		buf.WriteString(ts.builtinType(typ.Builtin))
@@ -1844,11 +1943,15 @@ func (ts *typescript) renderTyp(buf *bytes.Buffer, ns string, tt *schema.Type, n
 			indent()
 			buf.WriteString(ts.QuoteIfRequired(ts.fieldNameInStruct(field)))
 
-			if field.Optional || ts.isRecursive(field.Typ) {
+			optional := field.Optional || ts.isRecursive(field.Typ)
+			if optional && !ts.strictOptionals {
 				buf.WriteString("?")
 			}
 			buf.WriteString(": ")
 			ts.renderTyp(buf, ns, field.Typ, numIndents+1)
+			if optional && ts.strictOptionals && !ts.isNullable(field.Typ) {
+				buf.WriteString(" | null")
+			}
 			buf.WriteString("\n")
 
 			// Add another empty line if we have a doc comment
@@ -1920,6 +2023,262 @@ func (ts *typescript) getUnionCases(typ *schema.Type) []*schema.Type {
 	}
 }
 
+// writeValidators writes the functions checking that the required fields
+// of request payloads are set, for the request types seen while writing the services.
+func (ts *typescript) writeValidators() {
+	if len(ts.validators) == 0 {
+		return
+	}
+
+	ts.WriteString(`
+// missingFieldError is the error thrown when a required request field is missing.
+function missingFieldError(path: string): APIError {
+    return new APIError(400, { code: ErrCode.InvalidArgument, message: ` + "`missing required field ${path}`" + ` })
+}
+`)
+
+	// Writing a validator can add more validators for nested structs,
+	// so don't range over the slice.
+	for i := 0; i < len(ts.validators); i++ {
+		ts.writeValidator(ts.validators[i])
+	}
+}
+
+func (ts *typescript) writeValidator(declID uint32) {
+	fmt.Fprintf(ts, "\nfunction %s(v: any, path: string): void {\n", ts.validatorName(declID))
+	for _, f := range ts.md.Decls[declID].Type.GetStruct().GetFields() {
+		if f.Wire.GetCookie() != nil || encoding.IgnoreField(f) {
+			continue
+		}
+
+		name := ts.fieldNameInStruct(f)
+		ref := "v[" + strconv.Quote(name) + "]"
+		path := "path + " + strconv.Quote("."+name)
+
+		// Recursive fields are rendered as optional, so don't require them.
+		recursive := false
+		if n := f.Typ.GetNamed(); n != nil {
+			recursive = ts.typs.IsRecursiveRef(declID, n.Id)
+		}
+		if !f.Optional && !recursive {
+			fmt.Fprintf(ts, "    if (%s === undefined) {\n        throw missingFieldError(%s)\n    }\n", ref, path)
+		}
+
+		if id, isList, ok := ts.validatedStruct(f.Typ); ok {
+			validator := ts.validatorName(id)
+			if isList {
+				fmt.Fprintf(ts, "    if (Array.isArray(%s)) {\n", ref)
+				elemPath := "path + " + strconv.Quote("."+name+"[") + ` + i + "]"`
+				fmt.Fprintf(ts, "        %s.forEach((e: any, i: number) => %s(e, %s))\n", ref, validator, elemPath)
+			} else {
+				fmt.Fprintf(ts, "    if (%s !== undefined && %s !== null) {\n", ref, ref)
+				fmt.Fprintf(ts, "        %s(%s, %s)\n", validator, ref, path)
+			}
+			ts.WriteString("    }\n")
+		}
+	}
+	ts.WriteString("}\n")
+}
+
+// validatorName returns the name of the validator function for the given struct declaration,
+// queueing it to be written if it hasn't been seen before.
+func (ts *typescript) validatorName(declID uint32) string {
+	if !ts.seenValidators[declID] {
+		if ts.seenValidators == nil {
+			ts.seenValidators = make(map[uint32]bool)
+		}
+		ts.seenValidators[declID] = true
+		ts.validators = append(ts.validators, declID)
+	}
+	decl := ts.md.Decls[declID]
+	return "validate_" + validTSIdentifier(decl.Loc.PkgName) + "_" + validTSIdentifier(decl.Name)
+}
+
+// pathSegmentType returns the TypeScript type of the path parameter s.
+func pathSegmentType(s *meta.PathSegment) string {
+	switch s.ValueType {
+	case meta.PathSegment_STRING, meta.PathSegment_UUID:
+		return "string"
+	case meta.PathSegment_BOOL:
+		return "boolean"
+	case meta.PathSegment_INT8, meta.PathSegment_INT16, meta.PathSegment_INT32, meta.PathSegment_INT64, meta.PathSegment_INT,
+		meta.PathSegment_UINT8, meta.PathSegment_UINT16, meta.PathSegment_UINT32, meta.PathSegment_UINT64, meta.PathSegment_UINT:
+		return "number"
+	default:
+		panic(fmt.Sprintf("unhandled PathSegment type %s", s.ValueType))
+	}
+}
+
+// optionalRequest reports whether the request parameter of rpc's
+// generated method can be omitted. That's the case for endpoints whose
+// whole request is optional, unless the path is built from the request.
+func (ts *typescript) optionalRequest(rpc *meta.RPC) bool {
+	if !rpc.RequestOptional || rpc.StreamingRequest || rpc.StreamingResponse {
+		return false
+	}
+	if ts.sharedTypes {
+		return !hasPathParams(rpc)
+	}
+	return ts.pathFields(rpc) == nil
+}
+
+// pathFields returns the request fields tagged with the path wire location,
+// keyed by the name of the path parameter they are bound to.
+// It returns nil unless every path parameter of rpc is bound to a field,
+// in which case the path is built from the request rather than passed separately.
+func (ts *typescript) pathFields(rpc *meta.RPC) map[string]*encoding.ParameterEncoding {
+	if ts.sharedTypes || rpc.Proto == meta.RPC_RAW || rpc.StreamingRequest || rpc.StreamingResponse ||
+		rpc.RequestSchema == nil || !hasPathParams(rpc) {
+		return nil
+	}
+
+	rpcEncoding, err := encoding.DescribeRPC(ts.md, rpc, &encoding.Options{SrcNameTag: "json"})
+	if err != nil || len(rpcEncoding.DefaultRequestEncoding.PathParameters) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]*encoding.ParameterEncoding)
+	for _, f := range rpcEncoding.DefaultRequestEncoding.PathParameters {
+		fields[f.WireFormat] = f
+	}
+	for _, s := range rpc.Path.Segments {
+		switch s.Type {
+		case meta.PathSegment_LITERAL:
+		case meta.PathSegment_PARAM:
+			if fields[s.Value] == nil {
+				return nil
+			}
+		default:
+			// Wildcards span multiple segments and can't be bound to a single field.
+			return nil
+		}
+	}
+	return fields
+}
+
+// pathBuilderName returns the name of the function building the path of rpc,
+// and records that it needs to be written.
+func (ts *typescript) pathBuilderName(rpc *meta.RPC) string {
+	if !slices.Contains(ts.pathBuilders, rpc) {
+		ts.pathBuilders = append(ts.pathBuilders, rpc)
+	}
+	return fmt.Sprintf("path_%s_%s", rpc.ServiceName, rpc.Name)
+}
+
+// writePathBuilders writes the functions building the paths of the endpoints
+// whose path parameters are bound to request fields.
+func (ts *typescript) writePathBuilders() {
+	if len(ts.pathBuilders) == 0 {
+		return
+	}
+
+	ts.WriteString(`
+// encodePathParam encodes the value of the path parameter name as a single path segment.
+function encodePathParam(name: string, value: string | number | boolean): string {
+    const s = String(value)
+    if (s === "") {
+        throw new APIError(400, { code: ErrCode.InvalidArgument, message: ` + "`path parameter ${name} must not be empty`" + ` })
+    }
+    // "." and ".." would otherwise be resolved as relative path segments.
+    if (s === "." || s === "..") {
+        return s.replace(/\./g, "%2E")
+    }
+    return encodeURIComponent(s)
+}
+`)
+
+	for _, rpc := range ts.pathBuilders {
+		fields := ts.pathFields(rpc)
+
+		var params, path strings.Builder
+		for _, s := range rpc.Path.Segments {
+			path.WriteByte('/')
+			if s.Type == meta.PathSegment_LITERAL {
+				path.WriteString(s.Value)
+				continue
+			}
+
+			f := fields[s.Value]
+			if params.Len() > 0 {
+				params.WriteString(", ")
+			}
+			fmt.Fprintf(&params, "%s: %s", ts.QuoteIfRequired(f.SrcName), pathSegmentType(s))
+			fmt.Fprintf(&path, "${encodePathParam(%s, %s)}", ts.Quote(s.Value), ts.Dot("params", f.SrcName))
+		}
+
+		fmt.Fprintf(ts, "\nfunction %s(params: { %s }): string {\n    return `%s`\n}\n",
+			ts.pathBuilderName(rpc), params.String(), path.String())
+	}
+}
+
+// validatedStruct reports the struct declaration to validate for values of the given type,
+// looking through pointers and options, and whether the values are lists of that struct.
+func (ts *typescript) validatedStruct(typ *schema.Type) (declID uint32, isList, ok bool) {
+	unwrap := func(typ *schema.Type) *schema.Type {
+		for {
+			switch t := typ.Typ.(type) {
+			case *schema.Type_Pointer:
+				typ = t.Pointer.Base
+			case *schema.Type_Option:
+				typ = t.Option.Value
+			default:
+				return typ
+			}
+		}
+	}
+
+	typ = unwrap(typ)
+	if list := typ.GetList(); list != nil {
+		typ = unwrap(list.Elem)
+		isList = true
+	}
+	if n := typ.GetNamed(); n != nil && ts.md.Decls[n.Id].Type.GetStruct() != nil {
+		return n.Id, isList, true
+	}
+	return 0, false, false
+}
+
+// renderMap renders a map type. Maps with string keys are rendered as a Record,
+// and maps with numeric keys as an object with a numeric index signature.
+// Other key types can't be represented, and fail the generation.
+func (ts *typescript) renderMap(buf *bytes.Buffer, ns string, m *schema.Map, numIndents int) {
+	switch k := m.Key.Typ.(type) {
+	case *schema.Type_Builtin:
+		switch ts.builtinType(k.Builtin) {
+		case "string":
+			buf.WriteString("Record<string, ")
+			ts.renderTyp(buf, ns, m.Value, numIndents)
+			buf.WriteString(">")
+			return
+		case "number":
+			buf.WriteString("{ [key: number]: ")
+			ts.renderTyp(buf, ns, m.Value, numIndents)
+			buf.WriteString(" }")
+			return
+		}
+	case *schema.Type_Named:
+		buf.WriteString("Record<")
+		ts.renderTyp(buf, ns, m.Key, numIndents)
+		buf.WriteString(", ")
+		ts.renderTyp(buf, ns, m.Value, numIndents)
+		buf.WriteString(">")
+		return
+	}
+
+	// The key type can't be used as an object key in TypeScript,
+	// and rendering it with string keys would describe the wrong type.
+	var key bytes.Buffer
+	ts.renderTyp(&key, ns, m.Key, numIndents)
+	ts.errorf("unsupported map key type %s: TypeScript object keys must be strings or numbers", key.String())
+}
+
+// isNullable reports whether the rendered type already includes null.
+func (ts *typescript) isNullable(typ *schema.Type) bool {
+	return slices.ContainsFunc(ts.getUnionCases(typ), func(t *schema.Type) bool {
+		return t.GetLiteral().GetNull()
+	})
+}
+
 type bailout struct{ err error }
 
 func (ts *typescript) errorf(format string, args ...interface{}) {
@@ -2012,11 +2371,17 @@ func validTSIdentifier(input string) string {
 }
 
 func (ts *typescript) typeName(identifier string) string {
+	var name string
 	if ts.generatorVersion < TsExperimental {
-		return validTSIdentifier(identifier)
+		name = validTSIdentifier(identifier)
 	} else {
-		return idents.Convert(identifier, idents.PascalCase)
+		name = idents.Convert(identifier, idents.PascalCase)
 	}
+	// Unlike member names, namespace and type names can't be reserved words.
+	if isReservedWord(name) {
+		name = "_" + name
+	}
+	return name
 }
 
 func (ts *typescript) memberName(identifier string) string {
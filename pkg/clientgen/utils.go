@@ -3,16 +3,97 @@ package clientgen
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/fatih/structtag"
+	"google.golang.org/protobuf/proto"
+
 	"encr.dev/internal/version"
 	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
 )
 
 func doNotEditHeader() string {
 	return fmt.Sprintf("Code generated by the Encore %s client generator. DO NOT EDIT.", version.Version)
 }
 
+// parseRawTag parses the raw struct tag of a field.
+// The tag includes its quotes as written in the source,
+// but metadata from older versions stores it unquoted, so both are accepted.
+func parseRawTag(raw string) (*structtag.Tags, error) {
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = unquoted
+	}
+	return structtag.Parse(raw)
+}
+
+// isReservedWord reports whether id is a reserved word in JavaScript or TypeScript,
+// and therefore can't be used as the name of a variable, namespace or type.
+func isReservedWord(id string) bool {
+	switch id {
+	case "await", "break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do",
+		"else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "implements", "import",
+		"in", "instanceof", "interface", "let", "new", "null", "package", "private", "protected", "public",
+		"return", "static", "super", "switch", "this", "throw", "true", "try", "typeof", "var", "void", "while",
+		"with", "yield":
+		return true
+	default:
+		return false
+	}
+}
+
+// jsDocParams returns JSDoc @param lines describing the request fields documented by rpc.
+// Fields are referred to by the name they have in the generated request type.
+func jsDocParams(md *meta.Data, rpc *meta.RPC) []string {
+	if len(rpc.ParamDocs) == 0 {
+		return nil
+	}
+
+	jsonNames := make(map[string]string)
+	if n := rpc.RequestSchema.GetNamed(); n != nil && int(n.Id) < len(md.Decls) {
+		for _, f := range md.Decls[n.Id].Type.GetStruct().GetFields() {
+			if f.JsonName != "" && f.JsonName != "-" {
+				jsonNames[f.Name] = f.JsonName
+			}
+		}
+	}
+
+	var lines []string
+	for _, p := range rpc.ParamDocs {
+		name := p.Name
+		if jsonName, ok := jsonNames[name]; ok {
+			name = jsonName
+		}
+		doc := strings.Split(p.Doc, "\n")
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("@param params.%s %s", name, doc[0])))
+		for _, line := range doc[1:] {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+// goDocLines returns the lines of the doc comment for the client method calling rpc,
+// listing the documented request fields after the description.
+func goDocLines(rpc *meta.RPC) []string {
+	var lines []string
+	if rpc.Doc != nil && *rpc.Doc != "" {
+		lines = strings.Split(strings.TrimSpace(*rpc.Doc), "\n")
+	}
+	if len(rpc.ParamDocs) > 0 && len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	for _, p := range rpc.ParamDocs {
+		doc := strings.Split(p.Doc, "\n")
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("  - %s: %s", p.Name, doc[0])))
+		for _, line := range doc[1:] {
+			lines = append(lines, "    "+line)
+		}
+	}
+	return lines
+}
+
 func hasPublicRPC(svc *meta.Service) bool {
 	for _, rpc := range svc.Rpcs {
 		if rpc.AccessType != meta.RPC_PRIVATE {
@@ -22,6 +103,87 @@ func hasPublicRPC(svc *meta.Service) bool {
 	return false
 }
 
+// ResolveBuiltin resolves typ to its underlying builtin type, following named types
+// and looking through pointers and options.
+// Type parameters are resolved using the type arguments of the named types followed.
+// It reports false if the type is not ultimately a builtin, such as for structs, maps and lists.
+func ResolveBuiltin(md *meta.Data, typ *schema.Type) (schema.Builtin, bool) {
+	var (
+		// declID and args are the declaration typ is part of, and its type arguments.
+		declID uint32
+		args   []*schema.Type
+
+		// seen holds the instantiations followed so far, to detect cycles like "type T *T".
+		seen = make(map[string]bool)
+	)
+	for typ != nil {
+		switch t := typ.Typ.(type) {
+		case *schema.Type_Builtin:
+			return t.Builtin, true
+		case *schema.Type_Pointer:
+			typ = t.Pointer.Base
+		case *schema.Type_Option:
+			typ = t.Option.Value
+		case *schema.Type_TypeParameter:
+			if t.TypeParameter.DeclId != declID || int(t.TypeParameter.ParamIdx) >= len(args) {
+				return 0, false
+			}
+			typ = args[t.TypeParameter.ParamIdx]
+		case *schema.Type_Named:
+			if int(t.Named.Id) >= len(md.Decls) {
+				return 0, false
+			}
+			inst := &schema.Named{Id: t.Named.Id}
+			for _, arg := range t.Named.TypeArguments {
+				inst.TypeArguments = append(inst.TypeArguments, substTypeArgs(arg, declID, args))
+			}
+			key, err := proto.MarshalOptions{Deterministic: true}.Marshal(inst)
+			if err != nil || seen[string(key)] {
+				return 0, false
+			}
+			seen[string(key)] = true
+
+			declID, args = inst.Id, inst.TypeArguments
+			typ = md.Decls[inst.Id].Type
+		default:
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// substTypeArgs returns typ with the type parameters of the declaration declID
+// replaced by the corresponding type arguments in args.
+func substTypeArgs(typ *schema.Type, declID uint32, args []*schema.Type) *schema.Type {
+	if len(args) == 0 {
+		return typ
+	}
+	switch t := typ.Typ.(type) {
+	case *schema.Type_TypeParameter:
+		if t.TypeParameter.DeclId == declID && int(t.TypeParameter.ParamIdx) < len(args) {
+			return args[t.TypeParameter.ParamIdx]
+		}
+	case *schema.Type_Pointer:
+		return &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: substTypeArgs(t.Pointer.Base, declID, args)}}}
+	case *schema.Type_Option:
+		return &schema.Type{Typ: &schema.Type_Option{Option: &schema.Option{Value: substTypeArgs(t.Option.Value, declID, args)}}}
+	case *schema.Type_List:
+		return &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: substTypeArgs(t.List.Elem, declID, args)}}}
+	case *schema.Type_Map:
+		return &schema.Type{Typ: &schema.Type_Map{Map: &schema.Map{
+			Key:   substTypeArgs(t.Map.Key, declID, args),
+			Value: substTypeArgs(t.Map.Value, declID, args),
+		}}}
+	case *schema.Type_Named:
+		named := &schema.Named{Id: t.Named.Id}
+		for _, arg := range t.Named.TypeArguments {
+			named.TypeArguments = append(named.TypeArguments, substTypeArgs(arg, declID, args))
+		}
+		return &schema.Type{Typ: &schema.Type_Named{Named: named}}
+	}
+	return typ
+}
+
 type indentWriter struct {
 	w                *bytes.Buffer
 	depth            int
@@ -0,0 +1,124 @@
+package clientgen
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+func TestResolveBuiltin(t *testing.T) {
+	builtin := func(b schema.Builtin) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+	}
+	named := func(id uint32, args ...*schema.Type) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id, TypeArguments: args}}}
+	}
+	ptr := func(t *schema.Type) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: t}}}
+	}
+	opt := func(t *schema.Type) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Option{Option: &schema.Option{Value: t}}}
+	}
+	param := func(declID, idx uint32) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_TypeParameter{
+			TypeParameter: &schema.TypeParameterRef{DeclId: declID, ParamIdx: idx},
+		}}
+	}
+
+	md := &meta.Data{
+		Decls: []*schema.Decl{
+			{Id: 0, Name: "ID", Type: builtin(schema.Builtin_INT)},
+			{Id: 1, Name: "Params", Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{}}}},
+			{Id: 2, Name: "Wrapper", Type: &schema.Type{Typ: &schema.Type_TypeParameter{
+				TypeParameter: &schema.TypeParameterRef{DeclId: 2, ParamIdx: 0},
+			}}},
+			{Id: 3, Name: "Loop"},                                         // type Loop *Loop; set below
+			{Id: 4, Name: "Ref", Type: ptr(param(4, 0))},                  // type Ref[T any] *T
+			{Id: 5, Name: "Outer", Type: opt(named(4, ptr(param(5, 0))))}, // type Outer[T any] Option[Ref[*T]]
+			{Id: 6, Name: "GenericLoop"},                                  // type GenericLoop[T any] *GenericLoop[T]; set below
+		},
+	}
+	md.Decls[3].Type = ptr(named(3))
+	md.Decls[6].Type = ptr(named(6, param(6, 0)))
+
+	tests := []struct {
+		name   string
+		typ    *schema.Type
+		want   schema.Builtin
+		wantOk bool
+	}{
+		{
+			name:   "builtin",
+			typ:    builtin(schema.Builtin_STRING),
+			want:   schema.Builtin_STRING,
+			wantOk: true,
+		},
+		{
+			name:   "pointer_to_option",
+			typ:    ptr(opt(builtin(schema.Builtin_STRING))),
+			want:   schema.Builtin_STRING,
+			wantOk: true,
+		},
+		{
+			name:   "named_alias",
+			typ:    named(0),
+			want:   schema.Builtin_INT,
+			wantOk: true,
+		},
+		{
+			name:   "generic_type_argument",
+			typ:    named(2, ptr(named(0))),
+			want:   schema.Builtin_INT,
+			wantOk: true,
+		},
+		{
+			name:   "nested_type_parameter",
+			typ:    named(4, named(0)),
+			want:   schema.Builtin_INT,
+			wantOk: true,
+		},
+		{
+			name:   "type_argument_of_nested_generic",
+			typ:    named(5, builtin(schema.Builtin_STRING)),
+			want:   schema.Builtin_STRING,
+			wantOk: true,
+		},
+		{
+			name:   "unbound_type_parameter",
+			typ:    param(4, 0),
+			wantOk: false,
+		},
+		{
+			name:   "struct",
+			typ:    named(1),
+			wantOk: false,
+		},
+		{
+			name:   "list",
+			typ:    &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: builtin(schema.Builtin_STRING)}}},
+			wantOk: false,
+		},
+		{
+			name:   "cycle",
+			typ:    named(3),
+			wantOk: false,
+		},
+		{
+			name:   "generic_cycle",
+			typ:    named(6, builtin(schema.Builtin_STRING)),
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			got, ok := ResolveBuiltin(md, tt.typ)
+			c.Assert(ok, qt.Equals, tt.wantOk)
+			c.Assert(got, qt.Equals, tt.want)
+		})
+	}
+}
@@ -0,0 +1,543 @@
+// Package compat compares two versions of an application's metadata
+// and reports API changes together with their compatibility impact.
+package compat
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+// Severity describes the compatibility impact of a change.
+type Severity int
+
+const (
+	// NonBreaking changes are safe for existing clients.
+	NonBreaking Severity = iota
+	// Breaking changes may break existing clients.
+	Breaking
+)
+
+func (s Severity) String() string {
+	switch s {
+	case NonBreaking:
+		return "non-breaking"
+	case Breaking:
+		return "breaking"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Kind describes what kind of change was made.
+type Kind string
+
+const (
+	ServiceAdded    Kind = "service_added"
+	ServiceRemoved  Kind = "service_removed"
+	RPCAdded        Kind = "rpc_added"
+	RPCRemoved      Kind = "rpc_removed"
+	PathChanged     Kind = "path_changed"
+	MethodAdded     Kind = "method_added"
+	MethodRemoved   Kind = "method_removed"
+	AccessChanged   Kind = "access_changed"
+	ProtoChanged    Kind = "proto_changed"
+	StreamChanged   Kind = "stream_changed"
+	FieldAdded      Kind = "field_added"
+	FieldRemoved    Kind = "field_removed"
+	FieldOptional   Kind = "field_optional"
+	FieldRequired   Kind = "field_required"
+	FieldRenamed    Kind = "field_renamed"
+	TypeChanged     Kind = "type_changed"
+	RequestAdded    Kind = "request_added"
+	RequestRemoved  Kind = "request_removed"
+	RequestOptional Kind = "request_optional"
+	RequestRequired Kind = "request_required"
+	EncodingChanged Kind = "encoding_changed"
+	ResponseAdded   Kind = "response_added"
+	ResponseRemoved Kind = "response_removed"
+)
+
+// Change describes a single difference between two metadata snapshots.
+type Change struct {
+	Severity Severity
+	Kind     Kind
+
+	// Service and RPC identify where the change was made.
+	// RPC is empty for service-level changes.
+	Service string
+	RPC     string
+
+	// Path is the location of the change within the request or response
+	// schema, such as "request.User.Email". It is empty for changes
+	// that are not schema changes.
+	Path string
+
+	// Message is a human-readable description of the change.
+	Message string
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	b.WriteString(c.Service)
+	if c.RPC != "" {
+		b.WriteString("." + c.RPC)
+	}
+	if c.Path != "" {
+		b.WriteString(" " + c.Path)
+	}
+	fmt.Fprintf(&b, ": %s (%s)", c.Message, c.Severity)
+	return b.String()
+}
+
+// HasBreaking reports whether any of the changes is breaking.
+func HasBreaking(changes []Change) bool {
+	return slices.ContainsFunc(changes, func(c Change) bool {
+		return c.Severity == Breaking
+	})
+}
+
+// Diff compares the services and APIs in the old and new metadata
+// and returns the changes between them, classified by severity.
+//
+// Changes are reported in the order of the services and RPCs
+// in the old metadata, followed by any additions.
+func Diff(old, new *meta.Data) []Change {
+	d := &differ{old: old, new: new}
+
+	for _, oldSvc := range old.Svcs {
+		newSvc, ok := findSvc(new, oldSvc.Name)
+		if !ok {
+			d.add(Change{
+				Severity: Breaking,
+				Kind:     ServiceRemoved,
+				Service:  oldSvc.Name,
+				Message:  "service was removed",
+			})
+			continue
+		}
+		d.service(oldSvc, newSvc)
+	}
+
+	for _, newSvc := range new.Svcs {
+		if _, ok := findSvc(old, newSvc.Name); !ok {
+			d.add(Change{
+				Severity: NonBreaking,
+				Kind:     ServiceAdded,
+				Service:  newSvc.Name,
+				Message:  "service was added",
+			})
+		}
+	}
+
+	return d.changes
+}
+
+type differ struct {
+	old, new *meta.Data
+	changes  []Change
+
+	// Set for the RPC currently being compared.
+	svc, rpc string
+
+	// seen tracks the declarations already compared for the current RPC,
+	// to guard against infinite recursion for recursive types.
+	seen map[declPair]bool
+}
+
+type declPair struct {
+	old, new uint32
+	request  bool
+}
+
+// add records a change.
+func (d *differ) add(c Change) {
+	d.changes = append(d.changes, c)
+}
+
+// schemaChange records a schema change for the current RPC.
+func (d *differ) schemaChange(sev Severity, kind Kind, path, msg string, args ...any) {
+	d.add(Change{
+		Severity: sev,
+		Kind:     kind,
+		Service:  d.svc,
+		RPC:      d.rpc,
+		Path:     path,
+		Message:  fmt.Sprintf(msg, args...),
+	})
+}
+
+func (d *differ) service(oldSvc, newSvc *meta.Service) {
+	for _, oldRPC := range oldSvc.Rpcs {
+		newRPC, ok := findRPC(newSvc, oldRPC.Name)
+		if !ok {
+			d.add(Change{
+				Severity: Breaking,
+				Kind:     RPCRemoved,
+				Service:  oldSvc.Name,
+				RPC:      oldRPC.Name,
+				Message:  "endpoint was removed",
+			})
+			continue
+		}
+		d.endpoint(oldSvc.Name, oldRPC, newRPC)
+	}
+
+	for _, newRPC := range newSvc.Rpcs {
+		if _, ok := findRPC(oldSvc, newRPC.Name); !ok {
+			d.add(Change{
+				Severity: NonBreaking,
+				Kind:     RPCAdded,
+				Service:  newSvc.Name,
+				RPC:      newRPC.Name,
+				Message:  "endpoint was added",
+			})
+		}
+	}
+}
+
+func (d *differ) endpoint(svc string, oldRPC, newRPC *meta.RPC) {
+	d.svc, d.rpc = svc, oldRPC.Name
+	d.seen = make(map[declPair]bool)
+
+	if o, n := pathString(oldRPC.Path), pathString(newRPC.Path); o != n {
+		d.schemaChange(Breaking, PathChanged, "", "path changed from %s to %s", o, n)
+	} else {
+		// The paths have the same shape; make sure the parameter types are the same.
+		for i, seg := range oldRPC.Path.GetSegments() {
+			newSeg := newRPC.Path.Segments[i]
+			if seg.Type != meta.PathSegment_LITERAL && seg.ValueType != newSeg.ValueType {
+				d.schemaChange(Breaking, TypeChanged, "path."+newSeg.Value,
+					"path parameter type changed from %s to %s",
+					strings.ToLower(seg.ValueType.String()), strings.ToLower(newSeg.ValueType.String()))
+			}
+		}
+	}
+
+	for _, m := range oldRPC.HttpMethods {
+		if !hasMethod(newRPC.HttpMethods, m) {
+			d.schemaChange(Breaking, MethodRemoved, "", "HTTP method %s is no longer supported", m)
+		}
+	}
+	for _, m := range newRPC.HttpMethods {
+		if !hasMethod(oldRPC.HttpMethods, m) {
+			d.schemaChange(NonBreaking, MethodAdded, "", "HTTP method %s is now supported", m)
+		}
+	}
+
+	if o, n := oldRPC.AccessType, newRPC.AccessType; o != n {
+		sev := NonBreaking
+		if accessLevel(n) < accessLevel(o) {
+			sev = Breaking
+		}
+		d.schemaChange(sev, AccessChanged, "", "access changed from %s to %s",
+			strings.ToLower(o.String()), strings.ToLower(n.String()))
+	}
+
+	if o, n := oldRPC.Proto, newRPC.Proto; o != n {
+		d.schemaChange(Breaking, ProtoChanged, "", "endpoint changed from %s to %s",
+			strings.ToLower(o.String()), strings.ToLower(n.String()))
+	}
+
+	if o, n := oldRPC.StreamingRequest, newRPC.StreamingRequest; o != n {
+		d.schemaChange(Breaking, StreamChanged, "request", "request streaming changed from %t to %t", o, n)
+	}
+	if o, n := oldRPC.StreamingResponse, newRPC.StreamingResponse; o != n {
+		d.schemaChange(Breaking, StreamChanged, "response", "response streaming changed from %t to %t", o, n)
+	}
+
+	// Callers may omit an optional request, so making it required is breaking.
+	if o, n := oldRPC.RequestOptional, newRPC.RequestOptional; o && !n {
+		d.schemaChange(Breaking, RequestRequired, "request", "request payload became required")
+	} else if !o && n {
+		d.schemaChange(NonBreaking, RequestOptional, "request", "request payload became optional")
+	}
+
+	// Form-encoded endpoints still accept JSON bodies,
+	// so only moving away from form encoding breaks existing callers.
+	if o, n := oldRPC.BodyEncoding, newRPC.BodyEncoding; o != n {
+		sev := NonBreaking
+		if o == meta.RPC_FORM {
+			sev = Breaking
+		}
+		d.schemaChange(sev, EncodingChanged, "request", "request body encoding changed from %s to %s",
+			strings.ToLower(o.String()), strings.ToLower(n.String()))
+	}
+
+	switch o, n := oldRPC.RequestSchema, newRPC.RequestSchema; {
+	case o == nil && n != nil:
+		d.schemaChange(Breaking, RequestAdded, "request", "request payload was added")
+	case o != nil && n == nil:
+		d.schemaChange(Breaking, RequestRemoved, "request", "request payload was removed")
+	case o != nil && n != nil:
+		d.typ("request", true, o, n)
+	}
+
+	switch o, n := oldRPC.ResponseSchema, newRPC.ResponseSchema; {
+	case o == nil && n != nil:
+		d.schemaChange(NonBreaking, ResponseAdded, "response", "response payload was added")
+	case o != nil && n == nil:
+		d.schemaChange(Breaking, ResponseRemoved, "response", "response payload was removed")
+	case o != nil && n != nil:
+		d.typ("response", false, o, n)
+	}
+}
+
+// typ compares the old and new types at the given path.
+// If request is true the types are part of the request schema,
+// which affects which field changes are considered breaking.
+func (d *differ) typ(path string, request bool, o, n *schema.Type) {
+	switch ot := o.Typ.(type) {
+	case *schema.Type_Named:
+		nt, ok := n.Typ.(*schema.Type_Named)
+		if !ok {
+			d.typeChanged(path, o, n)
+			return
+		}
+		oldDecl, newDecl := d.old.Decls[ot.Named.Id], d.new.Decls[nt.Named.Id]
+		if declName(oldDecl) != declName(newDecl) || len(ot.Named.TypeArguments) != len(nt.Named.TypeArguments) {
+			d.typeChanged(path, o, n)
+			return
+		}
+		for i, arg := range ot.Named.TypeArguments {
+			d.typ(fmt.Sprintf("%s[%d]", path, i), request, arg, nt.Named.TypeArguments[i])
+		}
+
+		key := declPair{old: oldDecl.Id, new: newDecl.Id, request: request}
+		if !d.seen[key] {
+			d.seen[key] = true
+			d.typ(path, request, oldDecl.Type, newDecl.Type)
+		}
+
+	case *schema.Type_Struct:
+		nt, ok := n.Typ.(*schema.Type_Struct)
+		if !ok {
+			d.typeChanged(path, o, n)
+			return
+		}
+		d.fields(path, request, ot.Struct, nt.Struct)
+
+	case *schema.Type_List:
+		nt, ok := n.Typ.(*schema.Type_List)
+		if !ok {
+			d.typeChanged(path, o, n)
+			return
+		}
+		d.typ(path+"[]", request, ot.List.Elem, nt.List.Elem)
+
+	case *schema.Type_Map:
+		nt, ok := n.Typ.(*schema.Type_Map)
+		if !ok {
+			d.typeChanged(path, o, n)
+			return
+		}
+		d.typ(path+"[key]", request, ot.Map.Key, nt.Map.Key)
+		d.typ(path+"[value]", request, ot.Map.Value, nt.Map.Value)
+
+	case *schema.Type_Pointer:
+		nt, ok := n.Typ.(*schema.Type_Pointer)
+		if !ok {
+			d.typeChanged(path, o, n)
+			return
+		}
+		d.typ(path, request, ot.Pointer.Base, nt.Pointer.Base)
+
+	case *schema.Type_Option:
+		nt, ok := n.Typ.(*schema.Type_Option)
+		if !ok {
+			d.typeChanged(path, o, n)
+			return
+		}
+		d.typ(path, request, ot.Option.Value, nt.Option.Value)
+
+	case *schema.Type_Builtin:
+		nt, ok := n.Typ.(*schema.Type_Builtin)
+		if !ok || ot.Builtin != nt.Builtin {
+			d.typeChanged(path, o, n)
+		}
+
+	case *schema.Type_TypeParameter:
+		// The declaration id is positional, so only the
+		// parameter's index says whether it's the same parameter.
+		nt, ok := n.Typ.(*schema.Type_TypeParameter)
+		if !ok || ot.TypeParameter.ParamIdx != nt.TypeParameter.ParamIdx {
+			d.typeChanged(path, o, n)
+		}
+
+	default:
+		// Literals, unions and config values are compared as-is.
+		if !proto.Equal(o, n) {
+			d.typeChanged(path, o, n)
+		}
+	}
+}
+
+func (d *differ) typeChanged(path string, o, n *schema.Type) {
+	d.schemaChange(Breaking, TypeChanged, path, "type changed from %s to %s",
+		typeString(d.old, o), typeString(d.new, n))
+}
+
+// fields compares the fields of two struct types.
+func (d *differ) fields(path string, request bool, o, n *schema.Struct) {
+	for _, of := range o.Fields {
+		fieldPath := path + "." + of.Name
+		nf, ok := findField(n, of.Name)
+		if !ok {
+			d.schemaChange(Breaking, FieldRemoved, fieldPath, "field was removed")
+			continue
+		}
+
+		if ow, nw := wireName(of), wireName(nf); ow != nw {
+			d.schemaChange(Breaking, FieldRenamed, fieldPath, "wire name changed from %q to %q", ow, nw)
+		}
+
+		switch {
+		case of.Optional && !nf.Optional:
+			// Requests that omit the field are no longer valid.
+			sev := NonBreaking
+			if request {
+				sev = Breaking
+			}
+			d.schemaChange(sev, FieldRequired, fieldPath, "field is no longer optional")
+		case !of.Optional && nf.Optional:
+			// Clients may rely on the field always being present in responses.
+			sev := NonBreaking
+			if !request {
+				sev = Breaking
+			}
+			d.schemaChange(sev, FieldOptional, fieldPath, "field is now optional")
+		}
+
+		d.typ(fieldPath, request, of.Typ, nf.Typ)
+	}
+
+	for _, nf := range n.Fields {
+		if _, ok := findField(o, nf.Name); ok {
+			continue
+		}
+		fieldPath := path + "." + nf.Name
+		if request && !nf.Optional {
+			d.schemaChange(Breaking, FieldAdded, fieldPath, "required field was added")
+		} else if request {
+			d.schemaChange(NonBreaking, FieldAdded, fieldPath, "optional field was added")
+		} else {
+			d.schemaChange(NonBreaking, FieldAdded, fieldPath, "field was added")
+		}
+	}
+}
+
+func findSvc(md *meta.Data, name string) (*meta.Service, bool) {
+	idx := slices.IndexFunc(md.Svcs, func(s *meta.Service) bool { return s.Name == name })
+	if idx < 0 {
+		return nil, false
+	}
+	return md.Svcs[idx], true
+}
+
+func findRPC(svc *meta.Service, name string) (*meta.RPC, bool) {
+	idx := slices.IndexFunc(svc.Rpcs, func(r *meta.RPC) bool { return r.Name == name })
+	if idx < 0 {
+		return nil, false
+	}
+	return svc.Rpcs[idx], true
+}
+
+func findField(st *schema.Struct, name string) (*schema.Field, bool) {
+	idx := slices.IndexFunc(st.Fields, func(f *schema.Field) bool { return f.Name == name })
+	if idx < 0 {
+		return nil, false
+	}
+	return st.Fields[idx], true
+}
+
+// wireName describes how the field is named on the wire.
+func wireName(f *schema.Field) string {
+	switch loc := f.Wire.GetLocation().(type) {
+	case *schema.WireSpec_Header_:
+		return "header:" + cmp.Or(loc.Header.GetName(), f.Name)
+	case *schema.WireSpec_Query_:
+		return "query:" + f.QueryStringName
+	case *schema.WireSpec_Cookie_:
+		return "cookie:" + cmp.Or(loc.Cookie.GetName(), f.Name)
+	case *schema.WireSpec_Path_:
+		return "path:" + cmp.Or(loc.Path.GetName(), f.Name)
+	case *schema.WireSpec_HttpStatus_:
+		return "httpstatus"
+	}
+	return cmp.Or(f.JsonName, f.Name)
+}
+
+func hasMethod(methods []string, m string) bool {
+	return slices.Contains(methods, m) || slices.Contains(methods, "*")
+}
+
+// accessLevel orders access types by how permissive they are.
+func accessLevel(a meta.RPC_AccessType) int {
+	switch a {
+	case meta.RPC_PUBLIC:
+		return 2
+	case meta.RPC_AUTH:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func declName(d *schema.Decl) string {
+	return d.Loc.GetPkgPath() + "." + d.Name
+}
+
+// pathString renders the path with the parameter names omitted,
+// as renaming a path parameter does not affect clients.
+func pathString(path *meta.Path) string {
+	parts := make([]string, 0, len(path.GetSegments()))
+	for _, seg := range path.GetSegments() {
+		switch seg.Type {
+		case meta.PathSegment_LITERAL:
+			parts = append(parts, seg.Value)
+		case meta.PathSegment_PARAM:
+			parts = append(parts, ":")
+		case meta.PathSegment_WILDCARD:
+			parts = append(parts, "*")
+		case meta.PathSegment_FALLBACK:
+			parts = append(parts, "!")
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// typeString returns a short description of a type for use in messages.
+func typeString(md *meta.Data, t *schema.Type) string {
+	switch t := t.Typ.(type) {
+	case *schema.Type_Builtin:
+		return strings.ToLower(t.Builtin.String())
+	case *schema.Type_Named:
+		return md.Decls[t.Named.Id].Name
+	case *schema.Type_Struct:
+		return "struct"
+	case *schema.Type_List:
+		return "[]" + typeString(md, t.List.Elem)
+	case *schema.Type_Map:
+		return "map[" + typeString(md, t.Map.Key) + "]" + typeString(md, t.Map.Value)
+	case *schema.Type_Pointer:
+		return "*" + typeString(md, t.Pointer.Base)
+	case *schema.Type_Option:
+		return "option[" + typeString(md, t.Option.Value) + "]"
+	case *schema.Type_Union:
+		return "union"
+	case *schema.Type_Literal:
+		return "literal"
+	case *schema.Type_TypeParameter:
+		return "type parameter"
+	case *schema.Type_Config:
+		return "config"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,267 @@
+package compat
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+func TestDiff(t *testing.T) {
+	str := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING}}
+	integer := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_INT}}
+
+	// app returns metadata for an app with a single endpoint
+	// taking a request struct with the given fields.
+	app := func(fields ...*schema.Field) *meta.Data {
+		return &meta.Data{
+			Decls: []*schema.Decl{{
+				Id:   0,
+				Name: "Params",
+				Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: fields}}},
+				Loc:  &schema.Loc{PkgPath: "example.com/svc"},
+			}},
+			Svcs: []*meta.Service{{
+				Name: "svc",
+				Rpcs: []*meta.RPC{{
+					Name:          "Create",
+					ServiceName:   "svc",
+					AccessType:    meta.RPC_PUBLIC,
+					RequestSchema: &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: 0}}},
+					Path: &meta.Path{Segments: []*meta.PathSegment{
+						{Type: meta.PathSegment_LITERAL, Value: "create"},
+					}},
+					HttpMethods: []string{"POST"},
+				}},
+			}},
+		}
+	}
+
+	// withRPC modifies the endpoint of the given app metadata.
+	withRPC := func(md *meta.Data, fn func(rpc *meta.RPC)) *meta.Data {
+		fn(md.Svcs[0].Rpcs[0])
+		return md
+	}
+
+	tests := []struct {
+		name string
+		old  *meta.Data
+		new  *meta.Data
+		want []Change
+	}{
+		{
+			name: "unchanged",
+			old:  app(&schema.Field{Name: "Name", Typ: str}),
+			new:  app(&schema.Field{Name: "Name", Typ: str}),
+			want: nil,
+		},
+		{
+			name: "removed_field",
+			old:  app(&schema.Field{Name: "Name", Typ: str}, &schema.Field{Name: "Age", Typ: integer}),
+			new:  app(&schema.Field{Name: "Name", Typ: str}),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     FieldRemoved,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request.Age",
+				Message:  "field was removed",
+			}},
+		},
+		{
+			name: "added_optional_field",
+			old:  app(&schema.Field{Name: "Name", Typ: str}),
+			new:  app(&schema.Field{Name: "Name", Typ: str}, &schema.Field{Name: "Age", Typ: integer, Optional: true}),
+			want: []Change{{
+				Severity: NonBreaking,
+				Kind:     FieldAdded,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request.Age",
+				Message:  "optional field was added",
+			}},
+		},
+		{
+			name: "added_required_field",
+			old:  app(&schema.Field{Name: "Name", Typ: str}),
+			new:  app(&schema.Field{Name: "Name", Typ: str}, &schema.Field{Name: "Age", Typ: integer}),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     FieldAdded,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request.Age",
+				Message:  "required field was added",
+			}},
+		},
+		{
+			name: "changed_type",
+			old:  app(&schema.Field{Name: "Age", Typ: str}),
+			new:  app(&schema.Field{Name: "Age", Typ: integer}),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     TypeChanged,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request.Age",
+				Message:  "type changed from string to int",
+			}},
+		},
+		{
+			name: "request_became_required",
+			old:  withRPC(app(), func(rpc *meta.RPC) { rpc.RequestOptional = true }),
+			new:  app(),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     RequestRequired,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request",
+				Message:  "request payload became required",
+			}},
+		},
+		{
+			name: "request_became_optional",
+			old:  app(),
+			new:  withRPC(app(), func(rpc *meta.RPC) { rpc.RequestOptional = true }),
+			want: []Change{{
+				Severity: NonBreaking,
+				Kind:     RequestOptional,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request",
+				Message:  "request payload became optional",
+			}},
+		},
+		{
+			name: "became_raw",
+			old:  app(),
+			new:  withRPC(app(), func(rpc *meta.RPC) { rpc.Proto = meta.RPC_RAW }),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     ProtoChanged,
+				Service:  "svc",
+				RPC:      "Create",
+				Message:  "endpoint changed from regular to raw",
+			}},
+		},
+		{
+			name: "became_streaming",
+			old:  app(),
+			new:  withRPC(app(), func(rpc *meta.RPC) { rpc.StreamingResponse = true }),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     StreamChanged,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "response",
+				Message:  "response streaming changed from false to true",
+			}},
+		},
+		{
+			name: "became_form_encoded",
+			old:  app(),
+			new:  withRPC(app(), func(rpc *meta.RPC) { rpc.BodyEncoding = meta.RPC_FORM }),
+			want: []Change{{
+				Severity: NonBreaking,
+				Kind:     EncodingChanged,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request",
+				Message:  "request body encoding changed from json to form",
+			}},
+		},
+		{
+			name: "no_longer_form_encoded",
+			old:  withRPC(app(), func(rpc *meta.RPC) { rpc.BodyEncoding = meta.RPC_FORM }),
+			new:  app(),
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     EncodingChanged,
+				Service:  "svc",
+				RPC:      "Create",
+				Path:     "request",
+				Message:  "request body encoding changed from form to json",
+			}},
+		},
+		{
+			name: "removed_endpoint",
+			old:  app(),
+			new:  &meta.Data{Svcs: []*meta.Service{{Name: "svc"}}},
+			want: []Change{{
+				Severity: Breaking,
+				Kind:     RPCRemoved,
+				Service:  "svc",
+				RPC:      "Create",
+				Message:  "endpoint was removed",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			got := Diff(tt.old, tt.new)
+			c.Assert(got, qt.DeepEquals, tt.want)
+			c.Assert(HasBreaking(got), qt.Equals, HasBreaking(tt.want))
+		})
+	}
+}
+
+// TestDiffMovedGenericDecl checks that a generic declaration getting
+// a different id, with nothing else changed, is not reported as a change.
+func TestDiffMovedGenericDecl(t *testing.T) {
+	c := qt.New(t)
+	str := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING}}
+
+	// app returns metadata for an app with an endpoint taking a Wrapper[string],
+	// where Wrapper has the given id and the declarations before it are unrelated.
+	app := func(wrapperID uint32) *meta.Data {
+		md := &meta.Data{}
+		for id := uint32(0); id < wrapperID; id++ {
+			md.Decls = append(md.Decls, &schema.Decl{
+				Id:   id,
+				Name: fmt.Sprintf("Other%d", id),
+				Type: str,
+				Loc:  &schema.Loc{PkgPath: "example.com/svc"},
+			})
+		}
+		md.Decls = append(md.Decls, &schema.Decl{
+			Id:         wrapperID,
+			Name:       "Wrapper",
+			TypeParams: []*schema.TypeParameter{{Name: "T"}},
+			Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{{
+				Name: "Value",
+				Typ: &schema.Type{Typ: &schema.Type_TypeParameter{TypeParameter: &schema.TypeParameterRef{
+					DeclId:   wrapperID,
+					ParamIdx: 0,
+				}}},
+			}}}}},
+			Loc: &schema.Loc{PkgPath: "example.com/svc"},
+		})
+		md.Svcs = []*meta.Service{{
+			Name: "svc",
+			Rpcs: []*meta.RPC{{
+				Name:        "Create",
+				ServiceName: "svc",
+				AccessType:  meta.RPC_PUBLIC,
+				RequestSchema: &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{
+					Id:            wrapperID,
+					TypeArguments: []*schema.Type{str},
+				}}},
+				Path: &meta.Path{Segments: []*meta.PathSegment{
+					{Type: meta.PathSegment_LITERAL, Value: "create"},
+				}},
+				HttpMethods: []string{"POST"},
+			}},
+		}}
+		return md
+	}
+
+	old, moved := app(0), app(2)
+	c.Assert(Diff(old, moved), qt.HasLen, 0)
+	c.Assert(Hash(old), qt.Equals, Hash(moved))
+}
@@ -0,0 +1,194 @@
+package compat
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"slices"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+// Hash returns a stable hash of the API surface of md:
+// its services, endpoints and the types they reference.
+//
+// Documentation, source locations, declaration ids and raw struct tags
+// are ignored (only the field names and options parsed from the tags are
+// hashed), and services and endpoints are hashed in sorted order,
+// so two builds of the same API always hash to the same value.
+func Hash(md *meta.Data) string {
+	h := &hasher{md: md, h: sha256.New(), declSeen: make(map[uint32]bool)}
+
+	svcs := slices.Clone(md.Svcs)
+	slices.SortFunc(svcs, func(a, b *meta.Service) int { return cmp.Compare(a.Name, b.Name) })
+	for _, svc := range svcs {
+		h.str("svc")
+		h.str(svc.Name)
+		h.bool(svc.Internal)
+
+		rpcs := slices.Clone(svc.Rpcs)
+		slices.SortFunc(rpcs, func(a, b *meta.RPC) int { return cmp.Compare(a.Name, b.Name) })
+		for _, rpc := range rpcs {
+			h.rpc(rpc)
+		}
+	}
+
+	// Hash the declarations referenced by the endpoints. Hashing a declaration
+	// may reference further declarations, which are added to the queue.
+	for len(h.declQueue) > 0 {
+		decls := h.declQueue
+		h.declQueue = nil
+		slices.SortFunc(decls, func(a, b *schema.Decl) int { return cmp.Compare(declName(a), declName(b)) })
+		for _, d := range decls {
+			h.str("decl")
+			h.str(declName(d))
+			for _, p := range d.TypeParams {
+				h.str(p.Name)
+			}
+			h.typ(d.Type)
+		}
+	}
+
+	return hex.EncodeToString(h.h.Sum(nil))
+}
+
+type hasher struct {
+	md *meta.Data
+	h  hash.Hash
+
+	// declSeen tracks the declarations that have been queued for hashing.
+	declSeen  map[uint32]bool
+	declQueue []*schema.Decl
+}
+
+// str writes a length-prefixed string, so that adjacent values
+// can't be confused with one another.
+func (h *hasher) str(s string) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(s)))
+	h.h.Write(buf[:n])
+	h.h.Write([]byte(s))
+}
+
+func (h *hasher) bool(b bool) {
+	h.str(strconv.FormatBool(b))
+}
+
+// msg writes a message that does not reference any declarations.
+func (h *hasher) msg(m proto.Message) {
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	h.str(string(data))
+}
+
+func (h *hasher) rpc(rpc *meta.RPC) {
+	h.str("rpc")
+	h.str(rpc.Name)
+	h.str(rpc.AccessType.String())
+	h.str(rpc.Proto.String())
+	for _, seg := range rpc.Path.GetSegments() {
+		h.str(seg.Type.String())
+		h.str(seg.Value)
+		h.str(seg.ValueType.String())
+	}
+
+	methods := slices.Clone(rpc.HttpMethods)
+	slices.Sort(methods)
+	for _, m := range methods {
+		h.str(m)
+	}
+
+	h.bool(rpc.StreamingRequest)
+	h.bool(rpc.StreamingResponse)
+	h.bool(rpc.RequestOptional)
+	h.str(rpc.BodyEncoding.String())
+	h.str("request")
+	h.typ(rpc.RequestSchema)
+	h.str("response")
+	h.typ(rpc.ResponseSchema)
+	h.str("handshake")
+	h.typ(rpc.HandshakeSchema)
+}
+
+func (h *hasher) typ(t *schema.Type) {
+	if t == nil {
+		h.str("nil")
+		return
+	}
+	if t.Validation != nil {
+		h.str("validation")
+		h.msg(t.Validation)
+	}
+
+	switch t := t.Typ.(type) {
+	case *schema.Type_Named:
+		decl := h.md.Decls[t.Named.Id]
+		h.str("named")
+		h.str(declName(decl))
+		for _, arg := range t.Named.TypeArguments {
+			h.typ(arg)
+		}
+		if !h.declSeen[decl.Id] {
+			h.declSeen[decl.Id] = true
+			h.declQueue = append(h.declQueue, decl)
+		}
+
+	case *schema.Type_Struct:
+		h.str("struct")
+		for _, f := range t.Struct.Fields {
+			h.str(f.Name)
+			h.str(f.JsonName)
+			h.str(f.QueryStringName)
+			h.bool(f.Optional)
+			if f.Wire != nil {
+				h.msg(f.Wire)
+			}
+			h.typ(f.Typ)
+		}
+
+	case *schema.Type_Map:
+		h.str("map")
+		h.typ(t.Map.Key)
+		h.typ(t.Map.Value)
+
+	case *schema.Type_List:
+		h.str("list")
+		h.typ(t.List.Elem)
+
+	case *schema.Type_Pointer:
+		h.str("pointer")
+		h.typ(t.Pointer.Base)
+
+	case *schema.Type_Option:
+		h.str("option")
+		h.typ(t.Option.Value)
+
+	case *schema.Type_Union:
+		h.str("union")
+		for _, u := range t.Union.Types {
+			h.typ(u)
+		}
+
+	case *schema.Type_Config:
+		h.str("config")
+		h.bool(t.Config.IsValuesList)
+		h.typ(t.Config.Elem)
+
+	case *schema.Type_TypeParameter:
+		h.str("typeparam")
+		h.str(strconv.Itoa(int(t.TypeParameter.ParamIdx)))
+
+	case *schema.Type_Builtin:
+		h.str("builtin")
+		h.str(t.Builtin.String())
+
+	case *schema.Type_Literal:
+		h.str("literal")
+		h.msg(t.Literal)
+	}
+}
@@ -0,0 +1,76 @@
+package compat
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+)
+
+func TestHash(t *testing.T) {
+	str := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING}}
+
+	// app returns metadata for an app with two services, where the "users"
+	// service has an endpoint taking a request struct with the given fields.
+	app := func(doc string, fields ...*schema.Field) *meta.Data {
+		return &meta.Data{
+			Decls: []*schema.Decl{{
+				Id:   0,
+				Name: "Params",
+				Doc:  doc,
+				Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: fields}}},
+				Loc:  &schema.Loc{PkgPath: "example.com/users", StartPos: int32(len(doc))},
+			}},
+			Svcs: []*meta.Service{
+				{
+					Name: "users",
+					Rpcs: []*meta.RPC{{
+						Name:          "Create",
+						Doc:           &doc,
+						ServiceName:   "users",
+						AccessType:    meta.RPC_PUBLIC,
+						RequestSchema: &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: 0}}},
+						Path: &meta.Path{Segments: []*meta.PathSegment{
+							{Type: meta.PathSegment_LITERAL, Value: "users"},
+						}},
+						HttpMethods: []string{"POST"},
+					}},
+				},
+				{Name: "admin"},
+			},
+		}
+	}
+
+	name := &schema.Field{Name: "Name", Doc: "The user's name.", Typ: str}
+	base := Hash(app("Params are the parameters.", name))
+
+	c := qt.New(t)
+	c.Assert(base, qt.Equals, Hash(app("Params are the parameters.", name)))
+
+	// Changing comments does not affect the hash.
+	c.Assert(Hash(app("Params for creating a user.", &schema.Field{Name: "Name", Doc: "The name.", Typ: str})), qt.Equals, base)
+
+	// Neither does the order of services.
+	reordered := app("Params are the parameters.", name)
+	reordered.Svcs[0], reordered.Svcs[1] = reordered.Svcs[1], reordered.Svcs[0]
+	c.Assert(Hash(reordered), qt.Equals, base)
+
+	// Nor does the formatting of struct tags, as only their meaning is hashed.
+	tagged := &schema.Field{Name: "Name", Doc: "The user's name.", Typ: str, RawTag: `json:"Name"`}
+	c.Assert(Hash(app("Params are the parameters.", tagged)), qt.Equals, base)
+
+	// Adding a field does.
+	c.Assert(Hash(app("Params are the parameters.", name, &schema.Field{Name: "Email", Typ: str})), qt.Not(qt.Equals), base)
+
+	// So does changing the path.
+	moved := app("Params are the parameters.", name)
+	moved.Svcs[0].Rpcs[0].Path.Segments[0].Value = "accounts"
+	c.Assert(Hash(moved), qt.Not(qt.Equals), base)
+
+	// And changing the body encoding.
+	form := app("Params are the parameters.", name)
+	form.Svcs[0].Rpcs[0].BodyEncoding = meta.RPC_FORM
+	c.Assert(Hash(form), qt.Not(qt.Equals), base)
+}
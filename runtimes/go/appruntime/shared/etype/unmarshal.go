@@ -5,6 +5,8 @@ import (
 	stdjson "encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -180,6 +182,24 @@ func (u *Unmarshaller) ReadBody(body io.Reader) (payload []byte) {
 	return payload
 }
 
+// ReadOptionalBody is like ReadBody but treats an empty body as valid,
+// returning a nil payload for it.
+func (u *Unmarshaller) ReadOptionalBody(body io.Reader) (payload []byte) {
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		u.setErr("could not parse request body", "request_body", err)
+	}
+	return payload
+}
+
+// ParseForm parses the form-encoded body of req and returns its values.
+func (u *Unmarshaller) ParseForm(req *http.Request) url.Values {
+	if err := req.ParseForm(); err != nil {
+		u.setErr("could not parse request body", "request_body", err)
+	}
+	return req.PostForm
+}
+
 func (u *Unmarshaller) ParseJSON(field string, iter *jsoniter.Iterator, dst any) {
 	iter.ReadVal(dst)
 	u.setErr("invalid json parameter", field, iter.Error)
@@ -64,6 +64,7 @@ func (b *builder) Build() *meta.Data {
 		UncommittedChanges: b.app.BuildInfo.UncommittedChanges,
 		Experiments:        b.app.BuildInfo.Experiments.StringList(),
 		Language:           meta.Lang_GO,
+		QueryStringCasing:  queryStringCasing(b.app.BuildInfo.QueryStringCasing),
 	}
 	md := b.md
 
@@ -83,20 +84,29 @@ func (b *builder) Build() *meta.Data {
 
 		if fw, ok := svc.Framework.Get(); ok {
 			out.RelPath = b.relPath(fw.RootPkg.ImportPath)
+			if ss, ok := fw.ServiceStruct.Get(); ok {
+				out.Internal = ss.Internal
+			}
 			for _, ep := range fw.Endpoints {
+				doc, params := api.SplitParamDocs(ep.Doc)
 				rpc := &meta.RPC{
-					Name:           ep.Name,
-					Doc:            zeroNil(ep.Doc),
-					ServiceName:    svc.Name,
-					RequestSchema:  b.schemaTypeUnwrapPointer(ep.Request),
-					ResponseSchema: b.schemaTypeUnwrapPointer(ep.Response),
-					Proto:          meta.RPC_REGULAR,
-					Loc:            b.schemaLoc(ep.Decl.File, ep.Decl.AST),
-					Path:           b.apiPath(ep.Decl.AST.Pos(), ep.Path),
-					HttpMethods:    ep.HTTPMethods,
-					Tags:           ep.Tags.ToProto(),
-					Sensitive:      ep.Sensitive,
-					Expose:         make(map[string]*meta.RPC_ExposeOptions),
+					Name:            ep.Name,
+					Doc:             zeroNil(doc),
+					ParamDocs:       paramDocs(params),
+					ServiceName:     svc.Name,
+					RequestSchema:   b.schemaTypeUnwrapPointer(ep.Request),
+					ResponseSchema:  b.schemaTypeUnwrapPointer(ep.Response),
+					Proto:           meta.RPC_REGULAR,
+					Loc:             b.schemaLoc(ep.Decl.File, ep.Decl.AST),
+					Path:            b.apiPath(ep.Decl.AST.Pos(), ep.Path),
+					HttpMethods:     ep.HTTPMethods,
+					Tags:            ep.Tags.ToProto(),
+					Sensitive:       ep.Sensitive,
+					Expose:          make(map[string]*meta.RPC_ExposeOptions),
+					RequestOptional: ep.OptionalRequest,
+				}
+				if ep.FormEncoded {
+					rpc.BodyEncoding = meta.RPC_FORM
 				}
 				if ep.Raw {
 					rpc.Proto = meta.RPC_RAW
@@ -145,6 +155,8 @@ func (b *builder) Build() *meta.Data {
 		}
 	}
 
+	b.assignRPCIDs()
+
 	appPackages := b.app.Parse.AppPackages()
 	pkgByPath := make(map[paths.Pkg]*meta.Package, len(appPackages))
 	for _, pkg := range appPackages {
@@ -633,6 +645,45 @@ func (b *builder) keyspacePath(path *resourcepaths.Path) *meta.Path {
 	return res
 }
 
+// paramDocs converts the parameter docs of an endpoint to their metadata representation.
+func paramDocs(docs []api.ParamDoc) []*meta.RPC_ParamDoc {
+	var res []*meta.RPC_ParamDoc
+	for _, d := range docs {
+		res = append(res, &meta.RPC_ParamDoc{Name: d.Name, Doc: d.Doc})
+	}
+	return res
+}
+
+// queryStringCasing returns the casing convention to report in the metadata.
+// Apps that don't specify one use snake case.
+func queryStringCasing(casing meta.NameCasing) meta.NameCasing {
+	if casing == meta.NameCasing_NAME_CASING_UNSPECIFIED {
+		return meta.NameCasing_SNAKE_CASE
+	}
+	return casing
+}
+
+// assignRPCIDs numbers the RPCs in the metadata from 1, ordered by service name
+// and then RPC name, so clients can route by id instead of by name.
+//
+// The ids are positional, so adding or removing an RPC renumbers all
+// the RPCs sorted after it. They must not be persisted across builds.
+func (b *builder) assignRPCIDs() {
+	var rpcs []*meta.RPC
+	for _, svc := range b.md.Svcs {
+		rpcs = append(rpcs, svc.Rpcs...)
+	}
+	slices.SortFunc(rpcs, func(a, b *meta.RPC) int {
+		if n := cmp.Compare(a.ServiceName, b.ServiceName); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for i, rpc := range rpcs {
+		rpc.Id = uint32(i + 1)
+	}
+}
+
 func (b *builder) relPath(pkg paths.Pkg) string {
 	rel, ok := b.app.MainModule.Path.RelativePathToPkg(pkg)
 	if !ok {
@@ -0,0 +1,387 @@
+package legacymeta
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+	"encr.dev/v2/app"
+	"encr.dev/v2/internals/testutil"
+	"encr.dev/v2/parser"
+)
+
+func TestQueryStringCasing(t *testing.T) {
+	tests := []struct {
+		casing    meta.NameCasing
+		want      meta.NameCasing
+		wantNames map[string]string
+	}{
+		{
+			// Apps that don't configure a casing use snake case.
+			casing: meta.NameCasing_NAME_CASING_UNSPECIFIED,
+			want:   meta.NameCasing_SNAKE_CASE,
+			wantNames: map[string]string{
+				"UserID":   "user_id", // derived using the casing convention
+				"PageSize": "limit",   // set explicitly
+			},
+		},
+		{
+			casing: meta.NameCasing_SNAKE_CASE,
+			want:   meta.NameCasing_SNAKE_CASE,
+			wantNames: map[string]string{
+				"UserID":   "user_id",
+				"PageSize": "limit",
+			},
+		},
+		{
+			casing: meta.NameCasing_CAMEL_CASE,
+			want:   meta.NameCasing_CAMEL_CASE,
+			wantNames: map[string]string{
+				"UserID":   "userID",
+				"PageSize": "limit",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.casing.String(), func(t *testing.T) {
+			c := qt.New(t)
+			a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type Params struct {
+	UserID   string
+	PageSize int ` + "`query:\"limit\"`" + `
+}
+
+//encore:api public method=GET
+func List(ctx context.Context, p *Params) error { return nil }
+`)
+			tc := testutil.NewContext(c, false, a)
+			tc.Context.Build.QueryStringCasing = tt.casing
+			tc.FailTestOnErrors()
+			tc.GoModDownload()
+			defer tc.FailTestOnBailout()
+
+			res := parser.NewParser(tc.Context).Parse()
+			desc := app.ValidateAndDescribe(tc.Context, res)
+			md, _ := Compute(tc.Errs, desc)
+
+			c.Assert(md.QueryStringCasing, qt.Equals, tt.want)
+
+			names := make(map[string]string)
+			for _, f := range md.Decls[0].Type.GetStruct().Fields {
+				names[f.Name] = f.QueryStringName
+			}
+			c.Assert(names, qt.DeepEquals, tt.wantNames)
+
+			// The server decodes the query string using the same names.
+			ep := desc.Services[0].Framework.MustGet().Endpoints[0]
+			wireNames := make(map[string]string)
+			for _, p := range ep.RequestEncoding()[0].QueryParameters {
+				wireNames[p.SrcName] = p.WireName
+			}
+			c.Assert(wireNames, qt.DeepEquals, tt.wantNames)
+		})
+	}
+}
+
+func TestSumType(t *testing.T) {
+	c := qt.New(t)
+	a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- svc/svc.go --
+package svc
+
+import (
+	"context"
+
+	"encore.dev/pubsub"
+)
+
+type Shape interface {
+	isShape()
+}
+
+type Circle struct{ Radius float64 }
+type Square struct{ Side float64 }
+
+func (Circle) isShape()  {}
+func (*Square) isShape() {}
+
+type Event struct {
+	Shape Shape
+}
+
+var Events = pubsub.NewTopic[*Event]("events", pubsub.TopicConfig{
+	DeliveryGuarantee: pubsub.AtLeastOnce,
+})
+
+//encore:api public
+func Ping(ctx context.Context) error { return nil }
+`)
+	tc := testutil.NewContext(c, false, a)
+	tc.FailTestOnErrors()
+	tc.GoModDownload()
+	defer tc.FailTestOnBailout()
+
+	res := parser.NewParser(tc.Context).Parse()
+	desc := app.ValidateAndDescribe(tc.Context, res)
+	md, _ := Compute(tc.Errs, desc)
+
+	declNames := func(typs []*schema.Type) []string {
+		var names []string
+		for _, t := range typs {
+			names = append(names, md.Decls[t.GetNamed().Id].Name)
+		}
+		return names
+	}
+
+	// Sum types aren't supported in API schemas,
+	// so this uses a topic's message type.
+	event := md.Decls[md.PubsubTopics[0].MessageType.GetNamed().Id]
+	shape := md.Decls[event.Type.GetStruct().Fields[0].Typ.GetNamed().Id]
+	c.Assert(shape.Name, qt.Equals, "Shape")
+	c.Assert(shape.Type.GetUnion(), qt.IsNotNil)
+	c.Assert(declNames(shape.Type.GetUnion().Types), qt.DeepEquals, []string{"Circle", "Square"})
+}
+
+func TestRawJSONAndBytes(t *testing.T) {
+	c := qt.New(t)
+	a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- svc/svc.go --
+package svc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Params struct {
+	Data    []byte
+	Digest  [32]byte
+	Payload json.RawMessage
+}
+
+//encore:api public
+func Store(ctx context.Context, p *Params) error { return nil }
+`)
+	tc := testutil.NewContext(c, false, a)
+	tc.FailTestOnErrors()
+	tc.GoModDownload()
+	defer tc.FailTestOnBailout()
+
+	res := parser.NewParser(tc.Context).Parse()
+	desc := app.ValidateAndDescribe(tc.Context, res)
+	md, _ := Compute(tc.Errs, desc)
+
+	got := make(map[string]schema.Builtin)
+	for _, f := range md.Decls[0].Type.GetStruct().Fields {
+		got[f.Name] = f.Typ.GetBuiltin()
+	}
+	// Bytes are base64-encoded, while raw JSON is embedded as is.
+	c.Assert(got, qt.DeepEquals, map[string]schema.Builtin{
+		"Data":    schema.Builtin_BYTES,
+		"Digest":  schema.Builtin_BYTES,
+		"Payload": schema.Builtin_JSON,
+	})
+}
+
+func TestRPCIDs(t *testing.T) {
+	c := qt.New(t)
+	compute := func(files string) map[string]uint32 {
+		a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+` + files)
+		tc := testutil.NewContext(c, false, a)
+		tc.FailTestOnErrors()
+		tc.GoModDownload()
+		defer tc.FailTestOnBailout()
+
+		res := parser.NewParser(tc.Context).Parse()
+		desc := app.ValidateAndDescribe(tc.Context, res)
+		md, _ := Compute(tc.Errs, desc)
+
+		ids := make(map[string]uint32)
+		for _, svc := range md.Svcs {
+			for _, rpc := range svc.Rpcs {
+				ids[svc.Name+"."+rpc.Name] = rpc.Id
+			}
+		}
+		return ids
+	}
+
+	const users = `
+-- users/users.go --
+package users
+
+import "context"
+
+//encore:api public
+func Get(ctx context.Context) error { return nil }
+
+//encore:api public
+func Create(ctx context.Context) error { return nil }
+`
+	const billing = `
+-- billing/billing.go --
+package billing
+
+import "context"
+
+//encore:api public
+func Charge(ctx context.Context) error { return nil }
+`
+	want := map[string]uint32{
+		"billing.Charge": 1,
+		"users.Create":   2,
+		"users.Get":      3,
+	}
+	c.Assert(compute(users+billing), qt.DeepEquals, want)
+	// The ids don't depend on the order the services are declared in.
+	c.Assert(compute(billing+users), qt.DeepEquals, want)
+
+	// Removing an RPC renumbers the ones sorted after it.
+	c.Assert(compute(users), qt.DeepEquals, map[string]uint32{
+		"users.Create": 1,
+		"users.Get":    2,
+	})
+}
+
+func TestFieldExamples(t *testing.T) {
+	// compute computes the metadata for an app whose endpoint takes a struct
+	// with the given fields, in a file that also has the given declarations.
+	compute := func(c *qt.C, fields, decls string) (*meta.Data, *testutil.Context) {
+		a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type Params struct {
+` + fields + `
+}
+
+` + decls + `
+
+//encore:api public
+func Create(ctx context.Context, p *Params) error { return nil }
+`)
+		tc := testutil.NewContext(c, false, a)
+		tc.GoModDownload()
+		defer tc.FailTestOnBailout()
+
+		res := parser.NewParser(tc.Context).Parse()
+		desc := app.ValidateAndDescribe(tc.Context, res)
+		md, _ := Compute(tc.Errs, desc)
+		return md, tc
+	}
+
+	c := qt.New(t)
+	md, tc := compute(c, `
+	Name   string   `+"`example:\"Jane, Doe\"`"+`
+	Age    int      `+"`example:\"-42\"`"+`
+	Admin  bool     `+"`example:\"true\"`"+`
+	Score  *float64 `+"`example:\"4.5\"`"+`
+	Size   uint64   `+"`example:\"18446744073709551615\"`"+`
+	Code   Code     `+"`example:\"abc\"`"+`
+	Tags   []string `+"`example:\"a\"`"+`
+	Nickname string`, "type Code string")
+	c.Assert(tc.Errs.Len(), qt.Equals, 0)
+
+	got := make(map[string]*schema.Literal)
+	for _, f := range md.Decls[0].Type.GetStruct().Fields {
+		got[f.Name] = f.Example
+	}
+	c.Assert(got, qt.CmpEquals(protocmp.Transform()), map[string]*schema.Literal{
+		"Name":  {Value: &schema.Literal_Str{Str: "Jane, Doe"}},
+		"Age":   {Value: &schema.Literal_Int{Int: -42}},
+		"Admin": {Value: &schema.Literal_Boolean{Boolean: true}},
+		"Score": {Value: &schema.Literal_Float{Float: 4.5}},
+		"Size":  {Value: &schema.Literal_Uint{Uint: 18446744073709551615}},
+		// Named types are unwrapped to their underlying type.
+		"Code": {Value: &schema.Literal_Str{Str: "abc"}},
+		// Examples for unsupported types are ignored.
+		"Tags":     nil,
+		"Nickname": nil,
+	})
+
+	for _, field := range []string{
+		"Age int `example:\"many\"`",
+		"Admin bool `example:\"yes please\"`",
+		"Small int8 `example:\"200\"`",
+		"Count uint `example:\"-1\"`",
+	} {
+		c.Run(field, func(c *qt.C) {
+			_, tc := compute(c, field, "")
+			c.Assert(tc.Errs.Len(), qt.Equals, 1)
+			c.Assert(tc.Errs.At(0).Title(), qt.Equals, "Invalid example")
+		})
+	}
+}
+
+func TestTypeAlias(t *testing.T) {
+	c := qt.New(t)
+	a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- svc/svc.go --
+package svc
+
+import "context"
+
+type ID = string
+
+type Code string
+
+type Params struct {
+	ID   ID
+	Code Code
+}
+
+//encore:api public
+func Create(ctx context.Context, p *Params) error { return nil }
+`)
+	tc := testutil.NewContext(c, false, a)
+	tc.FailTestOnErrors()
+	tc.GoModDownload()
+	defer tc.FailTestOnBailout()
+
+	res := parser.NewParser(tc.Context).Parse()
+	desc := app.ValidateAndDescribe(tc.Context, res)
+	md, _ := Compute(tc.Errs, desc)
+
+	aliases := make(map[string]bool)
+	for _, d := range md.Decls {
+		aliases[d.Name] = d.Alias
+		if d.Name != "Params" {
+			// Both have string as their underlying type.
+			c.Assert(d.Type.GetBuiltin(), qt.Equals, schema.Builtin_STRING, qt.Commentf("decl %s", d.Name))
+		}
+	}
+	c.Assert(aliases, qt.DeepEquals, map[string]bool{
+		"Params": false,
+		"ID":     true,
+		"Code":   false,
+	})
+}
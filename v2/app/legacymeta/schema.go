@@ -3,14 +3,16 @@ package legacymeta
 import (
 	"fmt"
 	"go/ast"
+	"net/http"
+	"strconv"
 
 	"encr.dev/pkg/fns"
-	"encr.dev/pkg/idents"
 	"encr.dev/pkg/paths"
 	schema "encr.dev/proto/afterpiece/parser/schema/v1"
 	"encr.dev/v2/internals/pkginfo"
 	schemav2 "encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
+	"encr.dev/v2/parser/apis/api/apienc"
 	"github.com/fatih/structtag"
 )
 
@@ -125,18 +127,18 @@ func (b *builder) schemaType(typ schemav2.Type) *schema.Type {
 		}}
 
 	case schemav2.PointerType:
-		return &schema.Type{Typ: &schema.Type_Pointer{
+		return normalizeNullable(&schema.Type{Typ: &schema.Type_Pointer{
 			Pointer: &schema.Pointer{
 				Base: b.schemaType(typ.Elem),
 			},
-		}}
+		}})
 
 	case schemav2.OptionType:
-		return &schema.Type{Typ: &schema.Type_Option{
+		return normalizeNullable(&schema.Type{Typ: &schema.Type_Option{
 			Option: &schema.Option{
 				Value: b.schemaType(typ.Value),
 			},
-		}}
+		}})
 
 	case schemav2.TypeParamRefType:
 		return &schema.Type{Typ: &schema.Type_TypeParameter{
@@ -153,6 +155,42 @@ func (b *builder) schemaType(typ schemav2.Type) *schema.Type {
 	return nil
 }
 
+// normalizeNullable collapses nested pointer and option types,
+// which all encode the absence of a value the same way on the wire:
+//
+//   - **T becomes *T
+//   - *Option[T] becomes Option[T]
+//   - Option[*T] becomes Option[T]
+//   - Option[Option[T]] becomes Option[T]
+//
+// The inner type is expected to already be normalized,
+// so a single level of collapsing is sufficient.
+func normalizeNullable(typ *schema.Type) *schema.Type {
+	var inner *schema.Type
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Pointer:
+		inner = t.Pointer.Base
+	case *schema.Type_Option:
+		inner = t.Option.Value
+	default:
+		return typ
+	}
+
+	switch it := inner.GetTyp().(type) {
+	case *schema.Type_Option:
+		// Any nesting involving an option becomes a single option.
+		return inner
+	case *schema.Type_Pointer:
+		if _, isPtr := typ.Typ.(*schema.Type_Pointer); isPtr {
+			return inner
+		}
+		return &schema.Type{Typ: &schema.Type_Option{
+			Option: &schema.Option{Value: it.Pointer.Base},
+		}}
+	}
+	return typ
+}
+
 // schemaTypeUnwrapPointer returns the schema type for the given type,
 // but unwraps the initial pointer if it is one.
 // This is used for backwards compatibility with the legacy metadata,
@@ -173,7 +211,7 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 		JsonName:        "",
 		Optional:        false,
 		QueryStringName: "",
-		RawTag:          f.Tag.String(),
+		RawTag:          f.RawTag,
 		Tags:            nil,
 	}
 
@@ -203,16 +241,19 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 		}
 	}
 
-	// Treat option types as optional.
-	if schemautil.IsOption(f.Type) {
+	// Treat option types as optional. This includes pointers to options,
+	// which normalizeNullable collapses into a single option.
+	if schemautil.IsOption(f.Type) || field.Typ.GetOption() != nil {
 		field.Optional = true
 	}
 
-	// Set WireSpec for header fields
+	// Set WireSpec for header fields.
+	// Header names are case-insensitive, so store them in canonical form.
 	if header, _ := f.Tag.Get("header"); header != nil {
 		headerSpec := &schema.WireSpec_Header{}
 		if header.Name != "" {
-			headerSpec.Name = &header.Name
+			name := http.CanonicalHeaderKey(header.Name)
+			headerSpec.Name = &name
 		}
 		field.Wire = &schema.WireSpec{
 			Location: &schema.WireSpec_Header_{
@@ -257,24 +298,132 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 		}
 	}
 
+	// Set WireSpec for path parameter fields
+	if path, _ := f.Tag.Get("path"); path != nil {
+		pathSpec := &schema.WireSpec_Path{}
+		if path.Name != "" {
+			pathSpec.Name = &path.Name
+		}
+		field.Wire = &schema.WireSpec{
+			Location: &schema.WireSpec_Path_{
+				Path: pathSpec,
+			},
+		}
+	}
+
 	if js, _ := f.Tag.Get("json"); js != nil {
 		if v := js.Name; v != "" {
 			field.JsonName = v
 		}
 	}
 
+	if ex, _ := f.Tag.Get("example"); ex != nil {
+		if lit, err := b.exampleLiteral(field.Typ, ex.Value()); err == nil {
+			field.Example = lit
+		} else {
+			err := apienc.ErrInvalidExample(ex.Value())
+			if f.AST != nil && f.AST.Tag != nil {
+				err = err.AtGoNode(f.AST.Tag)
+			}
+			b.errs.Add(err)
+		}
+	}
+
 	if qs := getQueryTag(); qs != nil {
 		if v := qs.Name; v != "" {
 			field.QueryStringName = v
 		}
 	}
 	if field.QueryStringName == "" {
-		field.QueryStringName = idents.Convert(field.Name, idents.SnakeCase)
+		// Keep in sync with the QueryStringCasing reported in the metadata.
+		field.QueryStringName = apienc.QueryStringName(b.md.GetQueryStringCasing(), field.Name)
 	}
 
 	return field
 }
 
+// exampleLiteral parses an example value for a field of type typ.
+// Examples are only supported for builtin types, named types based on them,
+// and pointers and options of those; for other types it returns nil.
+// It reports an error if the value can't be parsed as typ.
+func (b *builder) exampleLiteral(typ *schema.Type, value string) (*schema.Literal, error) {
+	switch t := typ.GetTyp().(type) {
+	case *schema.Type_Pointer:
+		return b.exampleLiteral(t.Pointer.Base, value)
+	case *schema.Type_Option:
+		return b.exampleLiteral(t.Option.Value, value)
+	case *schema.Type_Named:
+		// The declaration's type is nil while it's still being computed,
+		// which is the case for recursive types.
+		if len(t.Named.TypeArguments) > 0 || int(t.Named.Id) >= len(b.md.Decls) {
+			return nil, nil
+		}
+		return b.exampleLiteral(b.md.Decls[t.Named.Id].Type, value)
+	case *schema.Type_Builtin:
+		return builtinExample(t.Builtin, value)
+	default:
+		return nil, nil
+	}
+}
+
+// builtinExample parses an example value for the builtin type typ.
+func builtinExample(typ schema.Builtin, value string) (*schema.Literal, error) {
+	switch typ {
+	case schema.Builtin_BOOL:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.Literal{Value: &schema.Literal_Boolean{Boolean: v}}, nil
+
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64:
+		v, err := strconv.ParseInt(value, 10, builtinBitSize(typ))
+		if err != nil {
+			return nil, err
+		}
+		return &schema.Literal{Value: &schema.Literal_Int{Int: v}}, nil
+
+	case schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64:
+		v, err := strconv.ParseUint(value, 10, builtinBitSize(typ))
+		if err != nil {
+			return nil, err
+		}
+		return &schema.Literal{Value: &schema.Literal_Uint{Uint: v}}, nil
+
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		v, err := strconv.ParseFloat(value, builtinBitSize(typ))
+		if err != nil {
+			return nil, err
+		}
+		return &schema.Literal{Value: &schema.Literal_Float{Float: v}}, nil
+
+	case schema.Builtin_STRING, schema.Builtin_BYTES, schema.Builtin_TIME, schema.Builtin_UUID,
+		schema.Builtin_USER_ID, schema.Builtin_DECIMAL:
+		return &schema.Literal{Value: &schema.Literal_Str{Str: value}}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// builtinBitSize returns the size in bits of the numeric builtin type typ,
+// in the form expected by the strconv parse functions.
+func builtinBitSize(typ schema.Builtin) int {
+	switch typ {
+	case schema.Builtin_INT8, schema.Builtin_UINT8:
+		return 8
+	case schema.Builtin_INT16, schema.Builtin_UINT16:
+		return 16
+	case schema.Builtin_INT32, schema.Builtin_UINT32, schema.Builtin_FLOAT32:
+		return 32
+	case schema.Builtin_INT64, schema.Builtin_UINT64, schema.Builtin_FLOAT64:
+		return 64
+	default:
+		// int and uint are platform-sized.
+		return 0
+	}
+}
+
 func (b *builder) configValue(typ schemav2.NamedType) *schema.Type {
 	switch typ.DeclInfo.Name {
 	case "Value", "Values":
@@ -351,14 +500,32 @@ func (b *builder) decl(decl schemav2.Decl) uint32 {
 		TypeParams: typeParams,
 		Doc:        typeDecl.Info.Doc,
 		Loc:        b.schemaLoc(file, decl.ASTNode()),
+		Alias:      typeDecl.IsAlias(),
 	}
 	b.md.Decls = append(b.md.Decls, d)
 
-	d.Type = b.schemaType(typeDecl.Type)
+	if variants, ok := typeDecl.Variants(); ok {
+		d.Type = b.sumType(variants)
+	} else {
+		d.Type = b.schemaType(typeDecl.Type)
+	}
 
 	return declIdx
 }
 
+// sumType returns the union of the given sum type variants.
+func (b *builder) sumType(variants []*schemav2.TypeDecl) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Union{
+		Union: &schema.Union{
+			Types: fns.Map(variants, func(v *schemav2.TypeDecl) *schema.Type {
+				return &schema.Type{Typ: &schema.Type_Named{
+					Named: &schema.Named{Id: b.decl(v)},
+				}}
+			}),
+		},
+	}}
+}
+
 func (b *builder) schemaLoc(f *pkginfo.File, node ast.Node) *schema.Loc {
 	tokenFile := f.Token()
 	sPos, ePos := tokenFile.Position(node.Pos()), tokenFile.Position(node.Pos())
@@ -0,0 +1,85 @@
+package legacymeta
+
+import (
+	"testing"
+
+	"github.com/fatih/structtag"
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"encr.dev/pkg/option"
+	schema "encr.dev/proto/afterpiece/parser/schema/v1"
+	schemav2 "encr.dev/v2/internals/schema"
+)
+
+func TestNormalizeNullable(t *testing.T) {
+	str := &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING}}
+	ptr := func(t *schema.Type) *schema.Type {
+		return normalizeNullable(&schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: t}}})
+	}
+	opt := func(t *schema.Type) *schema.Type {
+		return normalizeNullable(&schema.Type{Typ: &schema.Type_Option{Option: &schema.Option{Value: t}}})
+	}
+	rawPtr := func(t *schema.Type) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: t}}}
+	}
+	rawOpt := func(t *schema.Type) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Option{Option: &schema.Option{Value: t}}}
+	}
+
+	tests := []struct {
+		name string
+		got  *schema.Type
+		want *schema.Type
+	}{
+		{"*string", ptr(str), rawPtr(str)},
+		{"Option[string]", opt(str), rawOpt(str)},
+		{"**string", ptr(ptr(str)), rawPtr(str)},
+		{"*Option[string]", ptr(opt(str)), rawOpt(str)},
+		{"Option[*string]", opt(ptr(str)), rawOpt(str)},
+		{"Option[Option[string]]", opt(opt(str)), rawOpt(str)},
+		{"*Option[*string]", ptr(opt(ptr(str))), rawOpt(str)},
+		{"[]*string", &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: ptr(str)}}},
+			&schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: rawPtr(str)}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(tt.got, qt.CmpEquals(protocmp.Transform()), tt.want)
+		})
+	}
+}
+
+func TestStructFieldHeaderName(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want *string
+	}{
+		{tag: `header:"x-request-id"`, want: ptr("X-Request-Id")},
+		{tag: `header:"X-Request-ID"`, want: ptr("X-Request-Id")},
+		{tag: `header:"Authorization"`, want: ptr("Authorization")},
+		{tag: `header:",optional"`, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			c := qt.New(t)
+			tags, err := structtag.Parse(tt.tag)
+			c.Assert(err, qt.IsNil)
+
+			b := &builder{}
+			field := b.structField(schemav2.StructField{
+				Name: option.Some("RequestID"),
+				Type: schemav2.BuiltinType{Kind: schemav2.String},
+				Tag:  *tags,
+			})
+			c.Assert(field.Wire.GetHeader(), qt.IsNotNil)
+			c.Assert(field.Wire.GetHeader().Name, qt.DeepEquals, tt.want)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"go/ast"
+	"strings"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/app/apiframework"
@@ -76,11 +78,36 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 					// The request is always the first parameter after any path params (and after the ctx)
 					field, _ := schemautil.GetArgument(ep.Decl.AST.Type.Params, len(ep.Path.Params())+1)
 					d.validateType(pc, field.Type, ep.Request)
+					if ep.Access != api.Private {
+						d.validateExportedTypes(pc, field.Type, ep.Request)
+					}
 				}
 
 				if ep.Response != nil {
 					// The response is always the first return value
-					d.validateType(pc, ep.Decl.AST.Type.Results.List[0].Type, ep.Response)
+					usedAt := ep.Decl.AST.Type.Results.List[0].Type
+					d.validateType(pc, usedAt, ep.Response)
+					if ep.Access != api.Private {
+						d.validateExportedTypes(pc, usedAt, ep.Response)
+					}
+				}
+			}
+
+			// Check that the @param docs refer to request fields.
+			if len(ep.ParamDocs) > 0 {
+				fields := make(map[string]bool)
+				for _, enc := range ep.RequestEncoding() {
+					for _, p := range enc.AllParameters() {
+						fields[p.SrcName] = true
+					}
+				}
+				for _, p := range ep.ParamDocs {
+					if !fields[p.Name] {
+						pc.Errs.Add(
+							api.ErrUnknownParamDoc(p.Name).
+								AtGoNode(paramDocComment(ep.Decl.AST, p.Name), errors.AsError("documented here")),
+						)
+					}
 				}
 			}
 
@@ -126,3 +153,17 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 		}
 	}
 }
+
+// paramDocComment returns the comment documenting the request field name
+// in the doc comment of fd, or the function name if there is none.
+func paramDocComment(fd *ast.FuncDecl, name string) ast.Node {
+	if fd.Doc != nil {
+		for _, c := range fd.Doc.List {
+			_, rest, ok := strings.Cut(c.Text, "@param ")
+			if fields := strings.Fields(rest); ok && len(fields) > 0 && fields[0] == name {
+				return c
+			}
+		}
+	}
+	return fd.Name
+}
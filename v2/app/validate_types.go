@@ -13,14 +13,33 @@ import (
 // validateType validates the type of a field can be marshalled.
 // according to Encore's requirements.
 //
-// This walks the type recursively and validates the whole thing
+// This walks the type recursively and validates the whole thing.
 func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Type) {
 	// Convert generic types to their concrete types
 	typ = schemautil.ConcretizeGenericType(pc.Errs, typ)
 
+	// sumTypes tracks the interfaces of the sum types encountered,
+	// which are reported as such rather than as plain interfaces.
+	sumTypes := make(map[*ast.InterfaceType]bool)
+
 	// Walk the type recursively
 	schemautil.Walk(typ, func(t schema.Type) bool {
 		switch t := t.(type) {
+		case schema.NamedType:
+			decl := t.Decl()
+			iface, isIface := decl.Type.(schema.InterfaceType)
+			if !isIface || sumTypes[iface.AST] {
+				break
+			}
+			if _, ok := decl.Variants(); ok {
+				sumTypes[iface.AST] = true
+				pc.Errs.Add(
+					apienc.ErrSumTypeNotSupported.
+						AtGoNode(decl.AST.Name, errors.AsError("defined here")).
+						AtGoNode(usedAt, errors.AsHelp("used here")),
+				)
+			}
+
 		case schema.StructType:
 			for _, field := range t.Fields {
 				if field.IsAnonymous() {
@@ -42,6 +61,9 @@ func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Ty
 			)
 
 		case schema.InterfaceType:
+			if sumTypes[t.AST] {
+				break
+			}
 			pc.Errs.Add(
 				apienc.ErrInterfaceNotSupported.
 					AtGoNode(t.ASTExpr(), errors.AsError("defined here")).
@@ -51,3 +73,61 @@ func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Ty
 		return true
 	})
 }
+
+// validateExportedTypes warns about named types referenced by the type
+// of a public API that are not exported, since generated clients
+// cannot refer to them by name.
+//
+// This is not an error, as the API itself works fine; the warning
+// is only logged. Only the exported fields of structs are considered,
+// since unexported fields are not part of the API schema.
+func (d *Desc) validateExportedTypes(pc *parsectx.Context, usedAt ast.Node, typ schema.Type) {
+	seen := make(map[*schema.TypeDecl]bool)
+
+	var check func(t schema.Type)
+	check = func(t schema.Type) {
+		switch t := t.(type) {
+		case schema.NamedType:
+			for _, arg := range t.TypeArgs {
+				check(arg)
+			}
+
+			decl := t.Decl()
+			if seen[decl] {
+				return
+			}
+			seen[decl] = true
+
+			if !ast.IsExported(decl.Name) {
+				pc.Log.Warn().
+					Str("type", decl.Name).
+					Stringer("defined_at", pc.FS.Position(decl.AST.Name.Pos())).
+					Stringer("used_at", pc.FS.Position(usedAt.Pos())).
+					Msg("unexported type used in the schema of a public API; generated clients cannot refer to it by name")
+			}
+			check(decl.Type)
+			if variants, ok := decl.Variants(); ok {
+				for _, v := range variants {
+					check((&schema.TypeDeclRef{Decl: v}).ToType())
+				}
+			}
+
+		case schema.StructType:
+			for _, field := range t.Fields {
+				if field.IsExported() {
+					check(field.Type)
+				}
+			}
+		case schema.MapType:
+			check(t.Key)
+			check(t.Value)
+		case schema.ListType:
+			check(t.Elem)
+		case schema.PointerType:
+			check(t.Elem)
+		case schema.OptionType:
+			check(t.Value)
+		}
+	}
+	check(typ)
+}
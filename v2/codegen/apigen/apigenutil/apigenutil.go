@@ -75,6 +75,24 @@ func DecodeCookie(errs *perr.List, g *Group, httpReqExpr, paramExpr *Statement,
 	g.Line()
 }
 
+// DecodeForm is like DecodeQuery but for form-encoded request bodies,
+// which are read from the http request given by httpReqExpr.
+func DecodeForm(g *Group, httpReqExpr, paramExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+	g.Comment("Decode form-encoded request body")
+	g.Id("form").Op(":=").Add(dec.ParseForm(httpReqExpr))
+
+	for _, f := range params {
+		singleValExpr := Id("form").Dot("Get").Call(Lit(f.WireName))
+		listValExpr := Id("form").Index(Lit(f.WireName))
+		decodeExpr := dec.UnmarshalQueryOrHeader(f.Type, f.WireName, singleValExpr, listValExpr)
+		g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+	}
+	g.Line()
+}
+
 const jsonIterPkg = "github.com/json-iterator/go"
 
 // DecodeBody decodes an io.Reader request body into the given parameters.
@@ -85,6 +103,27 @@ func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *g
 
 	g.Comment("Decode request body")
 	g.Id("payload").Op(":=").Add(dec.ReadBody(ioReaderExpr))
+	decodeJSONPayload(g, paramsExpr, dec, params)
+	g.Line()
+}
+
+// DecodeOptionalBody is like DecodeBody but accepts an empty body,
+// leaving the parameters untouched in that case.
+func DecodeOptionalBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+
+	g.Comment("Decode request body, which may be omitted")
+	g.Id("payload").Op(":=").Add(dec.ReadOptionalBody(ioReaderExpr))
+	g.If(Len(Id("payload")).Op(">").Lit(0)).BlockFunc(func(g *Group) {
+		decodeJSONPayload(g, paramsExpr, dec, params)
+	})
+	g.Line()
+}
+
+// decodeJSONPayload decodes the JSON object in the "payload" variable into the given parameters.
+func decodeJSONPayload(g *Group, paramsExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
 	g.Id("iter").Op(":=").Qual(jsonIterPkg, "ParseBytes").Call(Id("json"), Id("payload"))
 	g.Line()
 
@@ -101,7 +140,6 @@ func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *g
 			Return(True()),
 		)).Block(),
 	)
-	g.Line()
 }
 
 // EncodeHeaders generates code for encoding HTTP headers into a http.Header map.
@@ -14,7 +14,7 @@ import (
 
 func Gen(gen *codegen.Generator, appDesc *app.Desc, ah *authhandler.AuthHandler, svcStruct option.Option[*codegen.VarDecl]) *codegen.VarDecl {
 	f := gen.File(ah.Decl.File.Pkg, "authhandler")
-	enc := apienc.DescribeAuth(gen.Errs, ah.Param)
+	enc := apienc.DescribeAuth(gen.Errs, gen.Build.QueryStringCasing, ah.Param)
 	gu := gen.Util
 	desc := f.VarDecl("AuthDesc", ah.Name)
 
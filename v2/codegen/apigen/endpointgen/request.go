@@ -248,7 +248,51 @@ func (d *requestDesc) renderRequestDecoding(g *Group, dec *genutil.TypeUnmarshal
 func (d *requestDesc) decodeRequestParameters(g *Group, dec *genutil.TypeUnmarshaller, req *apienc.RequestEncoding) {
 	apigenutil.DecodeHeaders(g, d.httpReqExpr().Dot("Header"), Id("params"), dec, req.HeaderParameters)
 	apigenutil.DecodeQuery(g, d.httpReqExpr().Dot("URL").Dot("Query").Call(), Id("params"), dec, req.QueryParameters)
-	apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	if d.ep.FormEncoded && len(req.BodyParameters) > 0 {
+		// Form-encoded endpoints still accept JSON bodies,
+		// which is what calls from other services send.
+		isForm := Qual("strings", "HasPrefix").Call(
+			d.httpReqExpr().Dot("Header").Dot("Get").Call(Lit("Content-Type")),
+			Lit("application/x-www-form-urlencoded"),
+		)
+		g.If(isForm).BlockFunc(func(g *Group) {
+			apigenutil.DecodeForm(g, d.httpReqExpr(), Id("params"), dec, req.BodyParameters)
+		}).Else().BlockFunc(func(g *Group) {
+			d.decodeBody(g, dec, req.BodyParameters)
+		})
+	} else {
+		d.decodeBody(g, dec, req.BodyParameters)
+	}
+	d.decodePathFields(g, req.PathParameters)
+}
+
+// decodeBody renders the code to decode the JSON request body into the request fields.
+// If the whole request is optional an empty body is accepted.
+func (d *requestDesc) decodeBody(g *Group, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if d.ep.OptionalRequest {
+		apigenutil.DecodeOptionalBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, params)
+	} else {
+		apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, params)
+	}
+}
+
+// decodePathFields renders the code to copy the already decoded path parameters
+// into the request fields tagged with `path`.
+func (d *requestDesc) decodePathFields(g *Group, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+
+	g.Comment("Copy path parameters")
+	for _, f := range params {
+		for i, seg := range d.ep.Path.Params() {
+			if seg.Value == f.WireName {
+				g.Id("params").Dot(f.SrcName).Op("=").Add(d.reqDataPathParamExpr(i))
+				break
+			}
+		}
+	}
+	g.Line()
 }
 
 // Clone returns the function literal to clone the request.
@@ -164,6 +164,18 @@ func (u *TypeUnmarshaller) ReadBody(bodyExpr *Statement) *Statement {
 	return u.unmarshallerExpr.Clone().Dot("ReadBody").Call(bodyExpr.Clone())
 }
 
+// ReadOptionalBody returns an expression to read the full request body into a []byte,
+// without reporting an error if the body is empty.
+func (u *TypeUnmarshaller) ReadOptionalBody(bodyExpr *Statement) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("ReadOptionalBody").Call(bodyExpr.Clone())
+}
+
+// ParseForm returns an expression to parse the form-encoded body
+// of the *http.Request given by httpReqExpr into url.Values.
+func (u *TypeUnmarshaller) ParseForm(httpReqExpr *Statement) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("ParseForm").Call(httpReqExpr.Clone())
+}
+
 // ParseJSON returns an expression to parse json.
 // It uses the iterator accessed through the given iteratorExpr to parse JSON into the given dstExpr.
 // The dstExpr must be a pointer value.
@@ -19,6 +19,7 @@ import (
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 	"encr.dev/v2/internals/perr"
 )
 
@@ -145,6 +146,11 @@ type BuildInfo struct {
 
 	// MigrationStrategy is the app-level strategy for database migrations.
 	MigrationStrategy string
+
+	// QueryStringCasing is the casing convention used to derive
+	// query string names for fields that don't specify one.
+	// If unspecified, snake case is used.
+	QueryStringCasing meta.NameCasing
 }
 
 // Trace traces the execution of a function.
@@ -9,6 +9,7 @@ import (
 	"go/scanner"
 	"go/token"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -174,6 +175,15 @@ func (l *List) At(i int) *errinsrc.ErrInSrc {
 	return l.errs[i]
 }
 
+// Filter removes the errors for which keep reports false.
+func (l *List) Filter(keep func(err *errinsrc.ErrInSrc) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = slices.DeleteFunc(l.errs, func(err *errinsrc.ErrInSrc) bool {
+		return !keep(err)
+	})
+}
+
 func (l *List) FS() *token.FileSet {
 	return l.fset
 }
@@ -1,9 +1,13 @@
 package schema
 
 import (
+	"cmp"
 	"go/ast"
+	"go/token"
 	"slices"
+	"sync"
 
+	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
 	"encr.dev/v2/internals/pkginfo"
@@ -51,6 +55,10 @@ type TypeDecl struct {
 	// TypeParams are any type parameters on this declaration.
 	// (note: instantiated types used within this declaration would not be captured here)
 	TypeParams []DeclTypeParam
+
+	// variants lazily computes the variants of a sum type declaration.
+	// It's nil if the declaration was not parsed by a Parser.
+	variants *lazyVariants
 }
 
 func (d *TypeDecl) Clone() *TypeDecl {
@@ -61,7 +69,46 @@ func (d *TypeDecl) Clone() *TypeDecl {
 		Name:       d.Name,
 		Type:       d.Type,
 		TypeParams: slices.Clone(d.TypeParams),
+		variants:   d.variants,
+	}
+}
+
+// IsAlias reports whether the declaration is a type alias,
+// like "type ID = string", as opposed to a defined type.
+func (d *TypeDecl) IsAlias() bool {
+	return d.AST != nil && d.AST.Assign.IsValid()
+}
+
+// Variants reports whether the declaration is a sum type, and if so
+// returns the types making up the sum, in declaration order.
+//
+// A sum type is a non-generic interface with at least one unexported method,
+// which means it can only be implemented by types in the same package.
+// Its variants are the non-generic types in the package that declare
+// all of the interface's methods. Methods promoted through embedding
+// are not considered.
+func (d *TypeDecl) Variants() ([]*TypeDecl, bool) {
+	if d.variants == nil {
+		return nil, false
 	}
+	return d.variants.Get()
+}
+
+// lazyVariants lazily computes the variants of a sum type declaration.
+type lazyVariants struct {
+	p    *Parser
+	decl *TypeDecl
+
+	once     sync.Once
+	variants []*TypeDecl
+	ok       bool
+}
+
+func (v *lazyVariants) Get() ([]*TypeDecl, bool) {
+	v.once.Do(func() {
+		v.variants, v.ok = v.p.parseVariants(v.decl)
+	})
+	return v.variants, v.ok
 }
 
 // DeclTypeParam represents a type parameter on a declaration.
@@ -159,6 +206,7 @@ func (p *Parser) ParseTypeDecl(d *pkginfo.PkgDeclInfo) *TypeDecl {
 		TypeParams: nil,
 		// Type is set below
 	}
+	decl.variants = &lazyVariants{p: p, decl: decl}
 	p.declsMu.Lock()
 	p.decls[key] = decl
 	p.declsMu.Unlock()
@@ -173,6 +221,67 @@ func (p *Parser) ParseTypeDecl(d *pkginfo.PkgDeclInfo) *TypeDecl {
 	return decl
 }
 
+// parseVariants computes the variants of decl if it's a sum type.
+// See (*TypeDecl).Variants for the rules.
+func (p *Parser) parseVariants(decl *TypeDecl) ([]*TypeDecl, bool) {
+	iface, ok := decl.Type.(InterfaceType)
+	if !ok || len(decl.TypeParams) > 0 || len(iface.EmbeddedIfaces) > 0 || len(iface.TypeLists) > 0 {
+		return nil, false
+	}
+
+	var methods []string
+	closed := false
+	for _, m := range iface.Methods {
+		for _, name := range m.Names {
+			methods = append(methods, name.Name)
+			closed = closed || !name.IsExported()
+		}
+	}
+	if !closed {
+		return nil, false
+	}
+
+	// Find the methods declared on each type in the package.
+	pkg := decl.File.Pkg
+	declared := make(map[string]map[string]bool)
+	for _, f := range pkg.Files {
+		for _, d := range f.AST().Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Recv.NumFields() != 1 {
+				continue
+			}
+			recv := p.resolveReceiverIdent(fd.Recv.List[0].Type)
+			if declared[recv.Name] == nil {
+				declared[recv.Name] = make(map[string]bool)
+			}
+			declared[recv.Name][fd.Name.Name] = true
+		}
+	}
+
+	var variants []*TypeDecl
+	for name, declMethods := range declared {
+		info := pkg.Names().PkgDecls[name]
+		if info == nil || info.Type != token.TYPE || name == decl.Name {
+			continue
+		}
+		if spec, ok := info.Spec.(*ast.TypeSpec); !ok || spec.TypeParams != nil {
+			continue
+		}
+		if !fns.All(methods, func(m string) bool { return declMethods[m] }) {
+			continue
+		}
+		variants = append(variants, p.ParseTypeDecl(info))
+	}
+	if len(variants) == 0 {
+		return nil, false
+	}
+
+	slices.SortFunc(variants, func(a, b *TypeDecl) int {
+		return cmp.Compare(a.AST.Pos(), b.AST.Pos())
+	})
+	return variants, true
+}
+
 // ParseFuncDecl parses the func from a package declaration.
 // It errors if the type is not a func declaration.
 func (p *Parser) ParseFuncDecl(file *pkginfo.File, fd *ast.FuncDecl) (*FuncDecl, bool) {
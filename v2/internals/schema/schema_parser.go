@@ -167,8 +167,12 @@ func (r *typeResolver) parseType(file *pkginfo.File, expr ast.Expr) Type {
 				}
 
 				// Parse the struct tags, if any.
-				var tags structtag.Tags
+				var (
+					tags   structtag.Tags
+					rawTag string
+				)
 				if field.Tag != nil {
+					rawTag = field.Tag.Value
 					val, _ := strconv.Unquote(field.Tag.Value)
 					t, err := structtag.Parse(val)
 					if err != nil {
@@ -185,11 +189,12 @@ func (r *typeResolver) parseType(file *pkginfo.File, expr ast.Expr) Type {
 
 				for _, name := range field.Names {
 					st.Fields = append(st.Fields, StructField{
-						AST:  field,
-						Name: option.Some(name.Name),
-						Type: typ,
-						Tag:  tags,
-						Doc:  docs,
+						AST:    field,
+						Name:   option.Some(name.Name),
+						Type:   typ,
+						Tag:    tags,
+						RawTag: rawTag,
+						Doc:    docs,
 					})
 				}
 			}
@@ -275,6 +275,45 @@ var x ` + test.typ + `
 	}
 }
 
+func TestParser_RawTag(t *testing.T) {
+	c := qt.New(t)
+	a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- code.go --
+package foo
+
+var x struct {
+	Tagged   string ` + "`json:\"tagged,omitempty\"  qs:\"t\"`" + `
+	Quoted   string "json:\"quoted\""
+	Empty    string ` + "``" + `
+	Untagged string
+}
+`)
+	tc := testutil.NewContext(c, false, a)
+	tc.GoModDownload()
+	tc.FailTestOnErrors()
+	defer tc.FailTestOnBailout()
+
+	l := pkginfo.New(tc.Context)
+	p := NewParser(tc.Context, l)
+	pkg := l.MustLoadPkg(token.NoPos, "example.com")
+	typeExpr := pkg.Names().PkgDecls["x"].Spec.(*ast.ValueSpec).Type
+	st := p.ParseType(pkg.Files[0], typeExpr).(StructType)
+
+	got := make(map[string]string)
+	for _, f := range st.Fields {
+		got[f.Name.MustGet()] = f.RawTag
+	}
+	c.Assert(got, qt.DeepEquals, map[string]string{
+		"Tagged":   "`json:\"tagged,omitempty\"  qs:\"t\"`",
+		"Quoted":   `"json:\"quoted\""`,
+		"Empty":    "``",
+		"Untagged": "",
+	})
+}
+
 func TestParser_ParseFuncDecl(t *testing.T) {
 	type testCase struct {
 		name     string
@@ -441,3 +480,59 @@ func fileForPkg(pkgName string, pkgPath paths.Pkg) *pkginfo.File {
 		ImportPath: pkgPath,
 	}}
 }
+
+func TestParser_Variants(t *testing.T) {
+	c := qt.New(t)
+	a := testutil.ParseTxtar(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- code.go --
+package foo
+
+type Shape interface {
+	isShape()
+	Area() float64
+}
+
+type Circle struct{ Radius float64 }
+type Square struct{ Side float64 }
+type Line struct{}
+
+func (Circle) isShape()         {}
+func (Circle) Area() float64    { return 0 }
+func (*Square) isShape()        {}
+func (*Square) Area() float64   { return 0 }
+func (Line) isShape()           {}
+
+type Open interface {
+	Area() float64
+}
+`)
+	tc := testutil.NewContext(c, false, a)
+	tc.GoModDownload()
+	tc.FailTestOnErrors()
+	defer tc.FailTestOnBailout()
+
+	l := pkginfo.New(tc.Context)
+	p := NewParser(tc.Context, l)
+	pkg := l.MustLoadPkg(token.NoPos, "example.com")
+
+	// Shape can only be implemented within the package, by Circle and Square.
+	// Line is missing the Area method.
+	variants, ok := p.ParseTypeDecl(pkg.Names().PkgDecls["Shape"]).Variants()
+	c.Assert(ok, qt.IsTrue)
+	names := make([]string, len(variants))
+	for i, v := range variants {
+		names[i] = v.Name
+	}
+	c.Assert(names, qt.DeepEquals, []string{"Circle", "Square"})
+
+	// Open can be implemented anywhere, so it's not a sum type.
+	_, ok = p.ParseTypeDecl(pkg.Names().PkgDecls["Open"]).Variants()
+	c.Assert(ok, qt.IsFalse)
+
+	// Neither are non-interface types.
+	_, ok = p.ParseTypeDecl(pkg.Names().PkgDecls["Circle"]).Variants()
+	c.Assert(ok, qt.IsFalse)
+}
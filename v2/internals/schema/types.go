@@ -87,6 +87,10 @@ type StructField struct {
 	Type Type
 	Doc  string
 	Tag  structtag.Tags
+
+	// RawTag is the tag literal as written in the source, including its quotes.
+	// It is empty if the field has no tag.
+	RawTag string
 }
 
 func (f *StructField) IsAnonymous() bool {
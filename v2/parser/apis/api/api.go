@@ -10,6 +10,7 @@ import (
 
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/option"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 	"encr.dev/v2/internals/perr"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/internals/resourcepaths"
@@ -31,10 +32,12 @@ const (
 )
 
 type Endpoint struct {
-	errs *perr.List
+	errs        *perr.List
+	queryCasing meta.NameCasing
 
 	Name             string
 	Doc              string
+	ParamDocs        []ParamDoc // from "@param Name description" lines in Doc
 	File             *pkginfo.File
 	Decl             *schema.FuncDecl
 	Access           AccessType
@@ -52,6 +55,16 @@ type Endpoint struct {
 	// meaning all request/response information will be redacted in traces.
 	Sensitive bool
 
+	// OptionalRequest indicates whether the whole request payload is optional,
+	// meaning callers may omit it entirely.
+	OptionalRequest      bool
+	OptionalRequestField option.Option[directive.Field]
+
+	// FormEncoded indicates whether the request body is encoded
+	// as application/x-www-form-urlencoded rather than as JSON.
+	FormEncoded      bool
+	FormEncodedField option.Option[directive.Field]
+
 	reqEncOnce  sync.Once
 	reqEncoding []*apienc.RequestEncoding
 
@@ -79,7 +92,7 @@ func (ep *Endpoint) RequestEncoding() []*apienc.RequestEncoding {
 
 	ep.reqEncOnce.Do(func() {
 		requestParam := ep.Decl.Type.Params[len(ep.Decl.Type.Params)-1]
-		ep.reqEncoding = apienc.DescribeRequest(ep.errs, requestParam, ep.Request, ep.HTTPMethodsField, ep.HTTPMethods...)
+		ep.reqEncoding = apienc.DescribeRequest(ep.errs, ep.queryCasing, requestParam, ep.Request, ep.HTTPMethodsField, ep.HTTPMethods...)
 	})
 	return ep.reqEncoding
 }
@@ -99,6 +112,10 @@ type ParseData struct {
 	Func *ast.FuncDecl
 	Dir  *directive.Directive
 	Doc  string
+
+	// QueryStringCasing is the casing convention for deriving
+	// query string names of request fields.
+	QueryStringCasing meta.NameCasing
 }
 
 // Parse parses an API endpoint. It may return nil on errors.
@@ -108,6 +125,7 @@ func Parse(d ParseData) *Endpoint {
 		return nil
 	}
 	rpc.errs = d.Errs
+	rpc.queryCasing = d.QueryStringCasing
 
 	// If there was no path, default to "pkg.Decl".
 	if rpc.Path == nil {
@@ -130,6 +148,7 @@ func Parse(d ParseData) *Endpoint {
 
 	rpc.Name = d.Func.Name.Name
 	rpc.Doc = d.Doc
+	_, rpc.ParamDocs = SplitParamDocs(d.Doc)
 	rpc.Decl = decl
 	rpc.File = d.File
 	rpc.Recv = decl.Recv
@@ -159,6 +178,8 @@ func Parse(d ParseData) *Endpoint {
 
 	// RequestEncoding will validate the request payload.
 	rpc.RequestEncoding()
+	validatePathFields(d.Errs, rpc)
+	validateFormFields(d.Errs, rpc)
 
 	// ResponseEncoding will validate the response payload.
 	rpc.ResponseEncoding()
@@ -244,6 +265,11 @@ func initTypedRPC(errs *perr.List, endpoint *Endpoint) {
 		errs.Add(errLastResultMustBeError.AtGoNode(err.AST))
 		return
 	}
+
+	// An optional request requires there to be a request payload.
+	if f, ok := endpoint.OptionalRequestField.Get(); ok && endpoint.Request == nil {
+		errs.Add(errOptionalRequestWithoutPayload.AtGoNode(f).AtGoNode(sig.AST.Params))
+	}
 }
 
 func initRawRPC(errs *perr.List, endpoint *Endpoint) {
@@ -297,6 +323,47 @@ func validatePathParam(errs *perr.List, param schema.Param, seg *resourcepaths.S
 	}
 }
 
+// validatePathFields validates that the request fields tagged with `path`
+// refer to a path parameter of the endpoint, and are of the same type.
+func validatePathFields(errs *perr.List, endpoint *Endpoint) {
+	encs := endpoint.RequestEncoding()
+	if len(encs) == 0 {
+		return
+	}
+
+	// The path parameters are the same for all encodings.
+	for _, f := range encs[0].PathParameters {
+		idx := slices.IndexFunc(endpoint.Path.Params(), func(s resourcepaths.Segment) bool {
+			return s.Value == f.WireName
+		})
+		if idx < 0 {
+			errs.Add(errUnknownPathField(f.SrcName, f.WireName).AtGoNode(f.Type.ASTExpr()).AtGoNode(endpoint.Path))
+			continue
+		}
+
+		seg := endpoint.Path.Params()[idx]
+		if b, ok := f.Type.(schema.BuiltinType); !ok || b.Kind != seg.ValueType {
+			errs.Add(errPathFieldTypeMismatch(f.SrcName, seg.Value).AtGoNode(f.Type.ASTExpr()).AtGoNode(seg))
+		}
+	}
+}
+
+// validateFormFields validates that the fields in the body of
+// a form-encoded request can be represented as form values.
+func validateFormFields(errs *perr.List, endpoint *Endpoint) {
+	if !endpoint.FormEncoded {
+		return
+	}
+
+	for _, enc := range endpoint.RequestEncoding() {
+		for _, f := range enc.BodyParameters {
+			if !schemautil.IsValidQueryType(f.Type) {
+				errs.Add(errInvalidFormField(f.SrcName).AtGoNode(f.Type.ASTExpr()))
+			}
+		}
+	}
+}
+
 // validateDirective validates the given encore:api directive
 // and returns an API with the respective fields set.
 func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bool) {
@@ -309,8 +376,8 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 
 	accessOptions := []string{"public", "private", "auth"}
 	ok := directive.Validate(errs, dir, directive.ValidateSpec{
-		AllowedOptions: append([]string{"raw", "sensitive"}, accessOptions...),
-		AllowedFields:  []string{"path", "method"},
+		AllowedOptions: append([]string{"raw", "sensitive", "optional"}, accessOptions...),
+		AllowedFields:  []string{"path", "method", "encoding"},
 
 		ValidateOption: func(errs *perr.List, opt directive.Field) (ok bool) {
 			// If this is an access option, check for duplicates.
@@ -329,6 +396,9 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 				rawTag = opt
 			case "sensitive":
 				endpoint.Sensitive = true
+			case "optional":
+				endpoint.OptionalRequest = true
+				endpoint.OptionalRequestField = option.Some(opt)
 			}
 
 			return true
@@ -364,6 +434,17 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 						}
 					}
 				}
+
+			case "encoding":
+				switch f.Value {
+				case "json":
+				case "form":
+					endpoint.FormEncoded = true
+				default:
+					errs.Add(errInvalidEndpointEncoding(f.Value).AtGoNode(f))
+					return false
+				}
+				endpoint.FormEncodedField = option.Some(f)
 			}
 			return true
 		},
@@ -389,6 +470,15 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 		errs.Add(errRawEndpointCantBePrivate.AtGoNode(rawTag, errors.AsError("declared as raw here")).AtGoNode(accessField, errors.AsError("set as private here")))
 		return nil, false
 	}
+	if f, ok := endpoint.OptionalRequestField.Get(); ok && endpoint.Raw {
+		errs.Add(errRawEndpointCantBeOptional.AtGoNode(rawTag, errors.AsError("declared as raw here")).AtGoNode(f, errors.AsError("set as optional here")))
+		return nil, false
+	}
+
+	if f, ok := endpoint.FormEncodedField.Get(); ok && endpoint.Raw {
+		errs.Add(errRawEndpointCantHaveEncoding.AtGoNode(rawTag, errors.AsError("declared as raw here")).AtGoNode(f, errors.AsError("encoding set here")))
+		return nil, false
+	}
 
 	return endpoint, true
 }
@@ -100,6 +100,150 @@ func Foo(ctx context.Context, key int) error {}
 				HTTPMethods: []string{"GET", "POST"},
 			},
 		},
+		{
+			name: "optional_request",
+			def: `
+//encore:api public optional
+func Foo(ctx context.Context, p *Params) error {}
+
+type Params struct {
+	Name string
+}
+`,
+			want: &Endpoint{
+				Name:                 "Foo",
+				Doc:                  "",
+				Access:               Public,
+				AccessField:          option.Some(directive.Field{Value: "public"}),
+				OptionalRequest:      true,
+				OptionalRequestField: option.Some(directive.Field{Value: "optional"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"POST"},
+			},
+		},
+		{
+			name: "optional_request_without_payload",
+			def: `
+//encore:api public optional
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*APIs declared with an optional request must have a request payload parameter\.`},
+		},
+		{
+			name:    "optional_raw",
+			imports: []string{"net/http"},
+			def: `
+//encore:api public raw optional
+func Raw(w http.ResponseWriter, req *http.Request) {}
+`,
+			wantErrs: []string{`.*Raw APIs cannot be declared as having an optional request\.`},
+		},
+		{
+			name: "path_field",
+			def: `
+//encore:api public path=/foo/:key method=PUT
+func Foo(ctx context.Context, key int, p *Params) error {}
+
+type Params struct {
+	Key  int ` + "`path:\"key\"`" + `
+	Name string
+}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo", ValueType: schema.String},
+					{Type: resourcepaths.Param, Value: "key", ValueType: schema.Int},
+				}},
+				HTTPMethods: []string{"PUT"},
+			},
+		},
+		{
+			name: "path_field_name_mismatch",
+			def: `
+//encore:api public path=/foo/:key method=PUT
+func Foo(ctx context.Context, key int, p *Params) error {}
+
+type Params struct {
+	Key int ` + "`path:\"id\"`" + `
+}
+`,
+			wantErrs: []string{`.*The field Key is tagged as path parameter "id", but the API path has no such parameter\.`},
+		},
+		{
+			name: "path_field_type_mismatch",
+			def: `
+//encore:api public path=/foo/:key method=PUT
+func Foo(ctx context.Context, key int, p *Params) error {}
+
+type Params struct {
+	Key string ` + "`path:\"key\"`" + `
+}
+`,
+			wantErrs: []string{`.*The field Key must be of the same type as the path parameter "key"\.`},
+		},
+		{
+			name: "form_encoding",
+			def: `
+//encore:api public encoding=form
+func Foo(ctx context.Context, p *Params) error {}
+
+type Params struct {
+	Name string
+	Tags []string
+}
+`,
+			want: &Endpoint{
+				Name:             "Foo",
+				Doc:              "",
+				Access:           Public,
+				AccessField:      option.Some(directive.Field{Value: "public"}),
+				FormEncoded:      true,
+				FormEncodedField: option.Some(directive.Field{Key: "encoding", Value: "form"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"POST"},
+			},
+		},
+		{
+			name: "form_encoding_invalid_field",
+			def: `
+//encore:api public encoding=form
+func Foo(ctx context.Context, p *Params) error {}
+
+type Params struct {
+	Attrs map[string]string
+}
+`,
+			wantErrs: []string{`.*The field Attrs cannot be form-encoded\..*`},
+		},
+		{
+			name: "unknown_encoding",
+			def: `
+//encore:api public encoding=xml
+func Foo(ctx context.Context, p *Params) error {}
+
+type Params struct {
+	Name string
+}
+`,
+			wantErrs: []string{`.*Invalid request encoding "xml"\..*`},
+		},
+		{
+			name:    "encoding_raw",
+			imports: []string{"net/http"},
+			def: `
+//encore:api public raw encoding=form
+func Raw(w http.ResponseWriter, req *http.Request) {}
+`,
+			wantErrs: []string{`.*Raw APIs cannot declare a request encoding\.`},
+		},
 		{
 			name:    "raw",
 			imports: []string{"net/http"},
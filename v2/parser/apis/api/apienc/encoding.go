@@ -9,6 +9,7 @@ import (
 	"encr.dev/pkg/errors"
 	"encr.dev/pkg/idents"
 	"encr.dev/pkg/option"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 	"encr.dev/v2/internals/perr"
 	"encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
@@ -25,6 +26,7 @@ const (
 	Query      WireLoc = "query"      // Parameter is placed in the query string
 	Body       WireLoc = "body"       // Parameter is placed in the body
 	Cookie     WireLoc = "cookie"     // Parameter is placed in cookies
+	Path       WireLoc = "path"       // Parameter is placed in the URL path
 	HTTPStatus WireLoc = "httpstatus" // Parameter represents the HTTP status code
 )
 
@@ -49,6 +51,10 @@ var (
 		omitEmptyOption: "omitempty",
 		overrideDefault: true,
 	}
+	PathTag = tagDescription{
+		location:        Path,
+		overrideDefault: true,
+	}
 	HTTPStatusTag = tagDescription{
 		location:        HTTPStatus,
 		overrideDefault: true,
@@ -60,6 +66,7 @@ var requestTags = map[string]tagDescription{
 	"query":  QueryTag,
 	"qs":     QsTag,
 	"header": HeaderTag,
+	"path":   PathTag,
 	"json":   JSONTag,
 }
 
@@ -92,6 +99,7 @@ type tagDescription struct {
 type encodingHints struct {
 	defaultLocation WireLoc
 	tags            map[string]tagDescription
+	queryCasing     meta.NameCasing
 }
 
 // APIEncoding expresses how an RPC should be encoded on the wire for both the request and responses.
@@ -150,10 +158,12 @@ type RequestEncoding struct {
 	HeaderParameters []*ParameterEncoding `json:"header_parameters"`
 	QueryParameters  []*ParameterEncoding `json:"query_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
+	// PathParameters are fields populated from the URL path parameters.
+	PathParameters []*ParameterEncoding `json:"path_parameters"`
 }
 
 func (r *RequestEncoding) AllParameters() []*ParameterEncoding {
-	return append(append(r.HeaderParameters, r.QueryParameters...), r.BodyParameters...)
+	return append(append(append(r.HeaderParameters, r.QueryParameters...), r.BodyParameters...), r.PathParameters...)
 }
 
 // ParameterEncoding expresses how a parameter should be encoded on the wire
@@ -185,7 +195,7 @@ func DescribeResponse(errs *perr.List, responseSchema schema.Type) *ResponseEnco
 		return &ResponseEncoding{}
 	}
 
-	fields, ok := describeParams(errs, &encodingHints{Body, responseTags}, responseStruct)
+	fields, ok := describeParams(errs, &encodingHints{Body, responseTags, meta.NameCasing_SNAKE_CASE}, responseStruct)
 	if !ok {
 		// describeParams already added the error to errs
 		return &ResponseEncoding{}
@@ -247,8 +257,9 @@ func keyDiff[T comparable, V any](src map[T]V, keys ...T) (diff []T) {
 }
 
 // DescribeRequest groups the provided httpMethods by default WireLoc and returns a RequestEncoding
-// per WireLoc
-func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema schema.Type, methodsField option.Option[directive.Field], httpMethods ...string) []*RequestEncoding {
+// per WireLoc. The queryCasing is used to derive the names of query string parameters
+// that don't specify one.
+func DescribeRequest(errs *perr.List, queryCasing meta.NameCasing, requestAST schema.Param, requestSchema schema.Type, methodsField option.Option[directive.Field], httpMethods ...string) []*RequestEncoding {
 	methodsByDefaultLocation := make(map[WireLoc][]string)
 	for _, m := range httpMethods {
 		switch m {
@@ -284,7 +295,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 	for location, methods := range methodsByDefaultLocation {
 		var fields map[WireLoc][]*ParameterEncoding
 
-		fields, ok := describeParams(errs, &encodingHints{location, requestTags}, st)
+		fields, ok := describeParams(errs, &encodingHints{location, requestTags, queryCasing}, st)
 		if !ok {
 			// report error in describeParams
 			return nil
@@ -328,7 +339,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			return nil
 		}
 
-		if keys := keyDiff(fields, Query, Header, Body); len(keys) > 0 {
+		if keys := keyDiff(fields, Query, Header, Body, Path); len(keys) > 0 {
 			err := errRequestInvalidLocation.AtGoNode(requestSchema.ASTExpr())
 
 			for _, k := range keys {
@@ -344,6 +355,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			QueryParameters:  fields[Query],
 			HeaderParameters: fields[Header],
 			BodyParameters:   fields[Body],
+			PathParameters:   fields[Path],
 		})
 	}
 
@@ -368,7 +380,8 @@ type AuthEncoding struct {
 
 // DescribeAuth generates a ParameterEncoding per field of the auth struct and returns it as
 // the AuthEncoding. If authSchema is nil it returns nil.
-func DescribeAuth(errs *perr.List, authSchema schema.Type) *AuthEncoding {
+// The queryCasing is used to derive the names of query string parameters that don't specify one.
+func DescribeAuth(errs *perr.List, queryCasing meta.NameCasing, authSchema schema.Type) *AuthEncoding {
 	if authSchema == nil {
 		return nil
 	}
@@ -387,7 +400,7 @@ func DescribeAuth(errs *perr.List, authSchema schema.Type) *AuthEncoding {
 		return nil
 	}
 
-	fields, ok := describeParams(errs, &encodingHints{Undefined, authTags}, st)
+	fields, ok := describeParams(errs, &encodingHints{Undefined, authTags, queryCasing}, st)
 	if !ok {
 		// reported by describeParams
 		return nil
@@ -430,16 +443,33 @@ func describeParams(errs *perr.List, encodingHints *encodingHints, payload schem
 	return paramByLocation, true
 }
 
-// formatName formats a parameter name with the default formatting for the location (e.g. snakecase for query)
-func formatName(location WireLoc, name string) string {
+// formatName formats a parameter name with the default formatting for the location
+// (e.g. the query string casing for query)
+func formatName(queryCasing meta.NameCasing, location WireLoc, name string) string {
 	switch location {
 	case Query:
-		return idents.Convert(name, idents.SnakeCase)
+		return QueryStringName(queryCasing, name)
 	default:
 		return name
 	}
 }
 
+// QueryStringName derives the query string name of a field
+// from its name using the given casing convention.
+// An unspecified casing means snake case.
+func QueryStringName(casing meta.NameCasing, name string) string {
+	switch casing {
+	case meta.NameCasing_CAMEL_CASE:
+		return idents.Convert(name, idents.CamelCase)
+	case meta.NameCasing_KEBAB_CASE:
+		return idents.Convert(name, idents.KebabCase)
+	case meta.NameCasing_AS_IS:
+		return name
+	default:
+		return idents.Convert(name, idents.SnakeCase)
+	}
+}
+
 // IgnoreField returns true if the field name is "-" is any of the valid request or response tags
 // or if the field is marked with encore:"httpstatus" (which shouldn't appear in client types)
 func IgnoreField(field schema.StructField) bool {
@@ -467,7 +497,7 @@ func describeParam(errs *perr.List, encodingHints *encodingHints, field schema.S
 	}
 	srcName := field.Name.MustGet()
 
-	defaultWireName := formatName(encodingHints.defaultLocation, srcName)
+	defaultWireName := formatName(encodingHints.queryCasing, encodingHints.defaultLocation, srcName)
 	param := ParameterEncoding{
 		OmitEmpty: false,
 		SrcName:   srcName,
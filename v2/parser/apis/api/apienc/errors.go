@@ -38,7 +38,7 @@ var (
 
 	errRequestInvalidLocation = errRange.New(
 		"Invalid request type",
-		"API request must only contain query, body, and header parameters.",
+		"API request must only contain query, body, header, and path parameters.",
 	)
 
 	errReservedHeaderPrefix = errRange.New(
@@ -56,6 +56,19 @@ var (
 		"Interfaces are not supported in API schemas.",
 	)
 
+	ErrSumTypeNotSupported = errRange.New(
+		"Invalid API schema",
+		"Sum types are not supported in API schemas, since requests and responses can't be decoded into an interface.",
+	)
+
+	ErrInvalidExample = errRange.Newf(
+		"Invalid example",
+		"The example %q is not a valid value for the field's type.",
+		errors.WithDetails("Examples must be valid values of the field's type. "+
+			"They're used for fields of builtin types, like strings, numbers and booleans, "+
+			"and named types based on them, and are ignored for other types."),
+	)
+
 	ErrAnonymousFieldsNotSupported = errRange.New(
 		"Invalid API schema",
 		"Anonymous fields are not supported in API schemas.",
@@ -134,4 +134,47 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"Invalid API call",
 		"Raw APIs cannot be called from within an Encore application.",
 	)
+
+	ErrUnknownParamDoc = errRange.Newf(
+		"Invalid API Documentation",
+		"The @param %s doesn't match any field of the request type.",
+
+		errors.WithDetails(baseHint),
+	)
+
+	errRawEndpointCantBeOptional = errRange.New(
+		"Invalid API Directive",
+		"Raw APIs cannot be declared as having an optional request.",
+	)
+
+	errOptionalRequestWithoutPayload = errRange.New(
+		"Invalid API Function",
+		"APIs declared with an optional request must have a request payload parameter.",
+	)
+
+	errUnknownPathField = errRange.Newf(
+		"Invalid request type",
+		"The field %s is tagged as path parameter %q, but the API path has no such parameter.",
+	)
+
+	errPathFieldTypeMismatch = errRange.Newf(
+		"Invalid request type",
+		"The field %s must be of the same type as the path parameter %q.",
+	)
+
+	errInvalidEndpointEncoding = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid request encoding %q. Supported encodings are json and form.",
+	)
+
+	errRawEndpointCantHaveEncoding = errRange.New(
+		"Invalid API Directive",
+		"Raw APIs cannot declare a request encoding.",
+	)
+
+	errInvalidFormField = errRange.Newf(
+		"Invalid request type",
+		"The field %s cannot be form-encoded. Fields in the body of a form-encoded request "+
+			"must be builtin types or slices of builtin types.",
+	)
 )
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"strings"
+)
+
+// ParamDoc documents a field of an endpoint's request.
+type ParamDoc struct {
+	Name string // the name of the request field
+	Doc  string // the description, possibly spanning multiple lines
+}
+
+// SplitParamDocs extracts the "@param Name description" lines from an endpoint's doc comment.
+// Indented lines following an @param line continue its description.
+// It returns the doc without those lines, and the parameter docs in order.
+func SplitParamDocs(doc string) (string, []ParamDoc) {
+	var (
+		kept   []string
+		params []ParamDoc
+		cur    *ParamDoc
+	)
+	for _, line := range strings.Split(doc, "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "@param "); ok {
+			name, desc, _ := strings.Cut(strings.TrimSpace(rest), " ")
+			params = append(params, ParamDoc{Name: name, Doc: strings.TrimSpace(desc)})
+			cur = &params[len(params)-1]
+			continue
+		}
+		if cur != nil && strings.TrimSpace(line) != "" && strings.TrimLeft(line, " \t") != line {
+			cur.Doc += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		cur = nil
+		kept = append(kept, line)
+	}
+	if len(params) == 0 {
+		return doc, nil
+	}
+
+	doc = strings.TrimRight(strings.Join(kept, "\n"), " \t\n")
+	if doc != "" {
+		doc += "\n"
+	}
+	return doc, params
+}
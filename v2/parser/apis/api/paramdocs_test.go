@@ -0,0 +1,56 @@
+package api
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSplitParamDocs(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		wantDoc    string
+		wantParams []ParamDoc
+	}{
+		{
+			name:    "no_params",
+			doc:     "DummyAPI is a dummy endpoint.\n",
+			wantDoc: "DummyAPI is a dummy endpoint.\n",
+		},
+		{
+			name:    "params",
+			doc:     "Create creates a user.\n\n@param Name the user's name\n@param Email the user's email\n",
+			wantDoc: "Create creates a user.\n",
+			wantParams: []ParamDoc{
+				{Name: "Name", Doc: "the user's name"},
+				{Name: "Email", Doc: "the user's email"},
+			},
+		},
+		{
+			name:    "multi_line",
+			doc:     "Create creates a user.\n@param Name the user's name,\n  as shown to others.\nIt fails if the user exists.\n",
+			wantDoc: "Create creates a user.\nIt fails if the user exists.\n",
+			wantParams: []ParamDoc{
+				{Name: "Name", Doc: "the user's name,\nas shown to others."},
+			},
+		},
+		{
+			name:    "only_params",
+			doc:     "@param Name\n",
+			wantDoc: "",
+			wantParams: []ParamDoc{
+				{Name: "Name", Doc: ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			doc, params := SplitParamDocs(tt.doc)
+			c.Assert(doc, qt.Equals, tt.wantDoc)
+			c.Assert(params, qt.DeepEquals, tt.wantParams)
+		})
+	}
+}
@@ -41,6 +41,8 @@ var Parser = &resourceparser.Parser{
 							Func:   decl,
 							Dir:    dir,
 							Doc:    doc,
+
+							QueryStringCasing: p.Context.Build.QueryStringCasing,
 						})
 
 						if ep != nil {
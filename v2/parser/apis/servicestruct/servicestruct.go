@@ -24,6 +24,11 @@ type ServiceStruct struct {
 	// Init is the function for initializing this group.
 	// It is nil if there is no initialization function.
 	Init option.Option[*schema.FuncDecl]
+
+	// Internal is true if the service is marked as internal
+	// (using "//encore:service internal"), which excludes it
+	// from generated clients by default.
+	Internal bool
 }
 
 func (ss *ServiceStruct) Kind() resource.Kind       { return resource.ServiceStruct }
@@ -46,8 +51,10 @@ type ParseData struct {
 
 // Parse parses the service struct in the provided type declaration.
 func Parse(d ParseData) *ServiceStruct {
-	// We don't allow anything on the directive besides "encore:service".
-	directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{})
+	// The only option we allow on the directive is "internal".
+	directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{
+		AllowedOptions: []string{"internal"},
+	})
 
 	// We only support encore:service directives directly on the type declaration,
 	// not on a group of type declarations.
@@ -64,8 +71,9 @@ func Parse(d ParseData) *ServiceStruct {
 	decl := d.Schema.ParseTypeDecl(declInfo)
 
 	ss := &ServiceStruct{
-		Decl: decl,
-		Doc:  d.Doc,
+		Decl:     decl,
+		Doc:      d.Doc,
+		Internal: d.Dir.HasOption("internal"),
 	}
 
 	// Find the init function for this service struct, if any.
@@ -44,6 +44,30 @@ type Foo struct {}
 				},
 			},
 		},
+		{
+			name: "internal",
+			def: `
+//encore:service internal
+type Foo struct {}
+`,
+			want: &ServiceStruct{
+				Decl: &schema.TypeDecl{
+					File:       file,
+					Name:       "Foo",
+					Type:       schema.StructType{},
+					TypeParams: nil,
+				},
+				Internal: true,
+			},
+		},
+		{
+			name: "error_unknown_option",
+			def: `
+//encore:service public
+type Foo struct {}
+`,
+			wantErrs: []string{`.*Unknown option "public"\. Options must be one of internal\.`},
+		},
 		{
 			name: "with_init_func",
 			def: `
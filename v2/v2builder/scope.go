@@ -0,0 +1,105 @@
+package v2builder
+
+import (
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/errinsrc"
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/pkginfo"
+)
+
+// packageDirs returns the directories of the given packages
+// and of the packages that (transitively) depend on them.
+func packageDirs(appPkgs []*pkginfo.Package, scope []string) map[string]bool {
+	inScope := scopedPackages(appPkgs, scope)
+
+	dirs := make(map[string]bool, len(inScope))
+	for _, pkg := range appPkgs {
+		if inScope[pkg.ImportPath] {
+			dirs[pkg.FSPath.ToIO()] = true
+		}
+	}
+	return dirs
+}
+
+// fileDirs returns the directory of the package containing file,
+// or nil if the file isn't in one of the app's packages.
+func fileDirs(appPkgs []*pkginfo.Package, file string) map[string]bool {
+	dir := filepath.Dir(file)
+	for _, pkg := range appPkgs {
+		if pkg.FSPath.ToIO() == dir {
+			return map[string]bool{dir: true}
+		}
+	}
+	return nil
+}
+
+// scopedError returns the error to report for errs, or nil if there are none.
+// If dirs is non-nil only the errors located in one of those directories
+// are reported; errors without a source location are always reported.
+//
+// An app with errors outside of dirs still can't be built,
+// so if all the errors are out of scope an error saying as much is returned.
+func scopedError(errs *perr.List, dirs map[string]bool) error {
+	if errs.Len() == 0 {
+		return nil
+	} else if dirs == nil {
+		return errs.AsError()
+	}
+
+	n := errs.Len()
+	filterErrorsToDirs(errs, dirs)
+	if errs.Len() > 0 {
+		return errs.AsError()
+	}
+	return errors.Newf("the app has %d error(s) outside the checked packages", n)
+}
+
+// filterErrorsToDirs removes the errors in errs that are not located
+// in one of the given directories. Errors without a source location are kept.
+func filterErrorsToDirs(errs *perr.List, dirs map[string]bool) {
+	errs.Filter(func(err *errinsrc.ErrInSrc) bool {
+		hasFile := false
+		for _, loc := range err.Params.Locations {
+			if loc.File == nil {
+				continue
+			}
+			hasFile = true
+			if dirs[filepath.Dir(loc.File.FullPath)] {
+				return true
+			}
+		}
+		return !hasFile
+	})
+}
+
+// scopedPackages computes the set of packages in scope:
+// the packages in scope and all the packages that depend on them.
+func scopedPackages(appPkgs []*pkginfo.Package, scope []string) map[paths.Pkg]bool {
+	// importedBy maps a package to the packages that import it.
+	importedBy := make(map[paths.Pkg][]paths.Pkg)
+	for _, pkg := range appPkgs {
+		for imp := range pkg.Imports {
+			importedBy[imp] = append(importedBy[imp], pkg.ImportPath)
+		}
+	}
+
+	inScope := make(map[paths.Pkg]bool)
+	queue := make([]paths.Pkg, 0, len(scope))
+	for _, s := range scope {
+		queue = append(queue, paths.Pkg(s))
+	}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if inScope[pkg] {
+			continue
+		}
+		inScope[pkg] = true
+		queue = append(queue, importedBy[pkg]...)
+	}
+	return inScope
+}
@@ -0,0 +1,200 @@
+package v2builder
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/v2/internals/testutil"
+	"encr.dev/v2/parser"
+)
+
+func TestScopeErrors(t *testing.T) {
+	const code = `
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- a/a.go --
+package a
+
+import "context"
+
+//encore:api public
+func A(ctx context.Context, p int) error { return nil }
+-- b/b.go --
+package b
+
+import "context"
+
+//encore:api public
+func B(ctx context.Context, p int) error { return nil }
+-- c/c.go --
+package c
+
+import (
+	"context"
+
+	"example.com/a"
+)
+
+var _ = a.A
+
+//encore:api public
+func C(ctx context.Context, p int) error { return nil }
+-- d/d.go --
+package d
+
+import "context"
+
+//encore:api public
+func D(ctx context.Context) error { return nil }
+`
+
+	tests := []struct {
+		name    string
+		scope   []string
+		want    []string // files with errors
+		wantErr string   // error reported when all errors are out of scope
+	}{
+		{
+			name:  "leaf",
+			scope: []string{"example.com/c"},
+			want:  []string{"c.go"},
+		},
+		{
+			name:  "with_dependents",
+			scope: []string{"example.com/a"},
+			want:  []string{"a.go", "c.go"},
+		},
+		{
+			name:  "multiple",
+			scope: []string{"example.com/b", "example.com/c"},
+			want:  []string{"b.go", "c.go"},
+		},
+		{
+			// The app is still broken, so the check must not succeed.
+			name:    "out_of_scope",
+			scope:   []string{"example.com/d"},
+			wantErr: "the app has 3 error(s) outside the checked packages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			a := testutil.ParseTxtar(code)
+			tc := testutil.NewContext(c, false, a)
+			tc.GoModDownload()
+			defer tc.FailTestOnBailout()
+
+			res := parser.NewParser(tc.Context).Parse()
+			c.Assert(tc.Errs.Len(), qt.Equals, 3)
+
+			err := scopedError(tc.Errs, packageDirs(res.AppPackages(), tt.scope))
+			c.Assert(err, qt.IsNotNil)
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, regexp.QuoteMeta(tt.wantErr))
+			}
+
+			var got []string
+			for i := 0; i < tc.Errs.Len(); i++ {
+				for _, loc := range tc.Errs.At(i).Params.Locations {
+					if loc.File != nil {
+						got = append(got, filepath.Base(loc.File.FullPath))
+						break
+					}
+				}
+			}
+			c.Assert(got, qt.DeepEquals, tt.want)
+		})
+	}
+}
+
+func TestScopeErrorsToFile(t *testing.T) {
+	const code = `
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- a/a.go --
+package a
+
+import "context"
+
+//encore:api public
+func A(ctx context.Context) error { return nil }
+-- a/invalid.go --
+package a
+
+import "context"
+
+//encore:api public
+func Invalid(ctx context.Context, p int) error { return nil }
+-- b/b.go --
+package b
+
+import "context"
+
+//encore:api public
+func B(ctx context.Context, p int) error { return nil }
+`
+
+	tests := []struct {
+		name      string
+		file      string
+		wantFound bool
+		want      []string // files with errors
+	}{
+		{
+			// The error is in another file of the same package.
+			name:      "same_package",
+			file:      "a/a.go",
+			wantFound: true,
+			want:      []string{"invalid.go"},
+		},
+		{
+			name:      "other_package",
+			file:      "b/b.go",
+			wantFound: true,
+			want:      []string{"b.go"},
+		},
+		{
+			// Files outside the app's packages report everything,
+			// like a full check.
+			name:      "unknown_package",
+			file:      "c/c.go",
+			wantFound: false,
+			want:      []string{"invalid.go", "b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			a := testutil.ParseTxtar(code)
+			tc := testutil.NewContext(c, false, a)
+			tc.GoModDownload()
+			defer tc.FailTestOnBailout()
+
+			res := parser.NewParser(tc.Context).Parse()
+			c.Assert(tc.Errs.Len(), qt.Equals, 2)
+
+			file := tc.MainModuleDir.Join(filepath.FromSlash(tt.file)).ToIO()
+			dirs := fileDirs(res.AppPackages(), file)
+			c.Assert(dirs != nil, qt.Equals, tt.wantFound)
+			c.Assert(scopedError(tc.Errs, dirs), qt.IsNotNil)
+
+			var got []string
+			for i := 0; i < tc.Errs.Len(); i++ {
+				for _, loc := range tc.Errs.At(i).Params.Locations {
+					if loc.File != nil {
+						got = append(got, filepath.Base(loc.File.FullPath))
+						break
+					}
+				}
+			}
+			c.Assert(got, qt.DeepEquals, tt.want)
+		})
+	}
+}
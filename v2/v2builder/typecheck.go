@@ -0,0 +1,127 @@
+package v2builder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/tools/go/packages"
+
+	"encr.dev/internal/env"
+	"encr.dev/internal/etrace"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/perr"
+)
+
+// TypeCheck type-checks a single package on its own, loading its
+// dependencies from export data rather than parsing them.
+//
+// It doesn't apply Encore's code generation or runtime overrides, so it's
+// only suitable for reporting errors within the package quickly.
+// The returned error lists the type errors in the package, if any.
+// Other problems, like syntax errors, are returned as plain errors,
+// since the full pipeline reports them with more context.
+func (*BuilderImpl) TypeCheck(ctx context.Context, p builder.TypeCheckParams) (*builder.TypeCheckResult, error) {
+	return etrace.Sync2(ctx, "", "v2builder.TypeCheck", func(ctx context.Context) (*builder.TypeCheckResult, error) {
+		b := p.Build
+		goroot := b.GoRoot.GetOrElseF(func() paths.FS {
+			return paths.RootedFSPath(env.EncoreGoRoot(), ".")
+		})
+		cgoEnabled := "0"
+		if b.CgoEnabled {
+			cgoEnabled = "1"
+		}
+
+		fset := token.NewFileSet()
+		cfg := &packages.Config{
+			// Without NeedDeps, dependencies are loaded from export data.
+			Mode:    packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes,
+			Context: ctx,
+			Dir:     p.Dir,
+			Env: append(os.Environ(),
+				"GOOS="+b.GOOS,
+				"GOARCH="+b.GOARCH,
+				"GOROOT="+goroot.ToIO(),
+				"CGO_ENABLED="+cgoEnabled,
+				"PATH="+goroot.Join("bin").ToIO()+string(filepath.ListSeparator)+os.Getenv("PATH"),
+			),
+			BuildFlags: []string{"-tags=" + strings.Join(b.BuildTags, ",")},
+			Fset:       fset,
+		}
+		pkgs, err := packages.Load(cfg, ".")
+		if err != nil {
+			return nil, errors.Wrap(err, "load package")
+		} else if len(pkgs) != 1 || len(pkgs[0].Syntax) == 0 {
+			return nil, errors.Newf("no Go package in %s", p.Dir)
+		}
+
+		// The go command reports the package's type errors as well when
+		// building its export data, so other errors only matter if
+		// the package type-checked cleanly.
+		pkg := pkgs[0]
+		for _, e := range pkg.Errors {
+			if e.Kind == packages.ParseError || (e.Kind != packages.TypeError && len(pkg.TypeErrors) == 0) {
+				return nil, errors.Newf("load package: %s", e.Msg)
+			}
+		}
+
+		res := &builder.TypeCheckResult{Shape: packageShape(fset, pkg.Syntax)}
+		if len(pkg.TypeErrors) == 0 {
+			return res, nil
+		}
+		errs := perr.NewList(ctx, fset)
+		for _, e := range pkg.TypeErrors {
+			errs.AddPos(e.Pos, e.Msg)
+		}
+		return res, errs.AsError()
+	})
+}
+
+// packageShape computes the shape of a package: a hash of its imports,
+// directives and top-level declarations, excluding function bodies
+// and ordinary comments.
+func packageShape(fset *token.FileSet, files []*ast.File) string {
+	files = slices.Clone(files)
+	slices.SortFunc(files, func(a, b *ast.File) int {
+		return strings.Compare(fset.File(a.Pos()).Name(), fset.File(b.Pos()).Name())
+	})
+
+	var buf bytes.Buffer
+	write := func(node any) {
+		_ = printer.Fprint(&buf, fset, node)
+		buf.WriteByte('\n')
+	}
+
+	for _, f := range files {
+		buf.WriteString("file " + filepath.Base(fset.File(f.Pos()).Name()) + "\n")
+		buf.WriteString("package " + f.Name.Name + "\n")
+		for _, group := range f.Comments {
+			for _, c := range group.List {
+				if strings.HasPrefix(c.Text, "//encore:") {
+					buf.WriteString(c.Text + "\n")
+				}
+			}
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				sig := *fn
+				sig.Doc, sig.Body = nil, nil
+				decl = &sig
+			}
+			write(decl)
+		}
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
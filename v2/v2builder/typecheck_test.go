@@ -0,0 +1,218 @@
+package v2builder
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/builder"
+	"encr.dev/v2/internals/perr"
+)
+
+// TestTypeCheckMatchesFullCheck checks that type-checking a single package
+// reports the same errors within it as the full check does.
+func TestTypeCheckMatchesFullCheck(t *testing.T) {
+	c := qt.New(t)
+	ar := txtar.Parse([]byte(`
+-- go.mod --
+module example.com
+require encore.dev v1.52.0
+-- encore.app --
+{"id": ""}
+-- svc/svc.go --
+package svc
+
+import "context"
+
+//encore:api public
+func Ping(ctx context.Context) error {
+	return helper()
+}
+-- svc/helper.go --
+package svc
+
+func helper() error {
+	var n int = "one"
+	_ = n
+	return nil
+}
+`))
+	root := c.TempDir()
+	c.Assert(txtar.Write(ar, root), qt.IsNil)
+
+	ctx := context.Background()
+	bld := New()
+	params := builder.ParseParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        apps.NewInstance(root, "app", ""),
+		WorkingDir: ".",
+	}
+
+	tc, err := bld.TypeCheck(ctx, builder.TypeCheckParams{
+		Build: params.Build,
+		App:   params.App,
+		Dir:   filepath.Join(root, "svc"),
+	})
+	c.Assert(tc, qt.IsNotNil, qt.Commentf("err: %v", err))
+	var list *perr.ListAsErr
+	c.Assert(err, qt.ErrorAs, &list)
+	var single []string
+	for _, e := range list.ErrorList() {
+		for _, loc := range e.Params.Locations {
+			single = append(single, fmt.Sprintf("%s:%d:%d: %s",
+				filepath.Base(loc.File.FullPath), loc.Start.Line, loc.Start.Col, e.Params.Summary))
+		}
+	}
+	c.Assert(single, qt.DeepEquals, []string{
+		`helper.go:4:14: cannot use "one" (untyped string constant) as int value in variable declaration`,
+	})
+
+	// The full check reports compiler errors as the go command prints them.
+	parse, err := bld.Parse(ctx, params)
+	c.Assert(err, qt.IsNil)
+	_, err = bld.Compile(ctx, builder.CompileParams{
+		Build: params.Build,
+		App:   params.App,
+		Parse: parse,
+	})
+	c.Assert(err, qt.IsNotNil)
+	var full []string
+	for _, m := range compilerError.FindAllStringSubmatch(err.Error(), -1) {
+		full = append(full, filepath.Base(m[1])+m[2])
+	}
+	c.Assert(full, qt.DeepEquals, single)
+}
+
+var compilerError = regexp.MustCompile(`(?m)^\s*(\S+\.go)(:\d+:\d+: .*)$`)
+
+func TestPackageShape(t *testing.T) {
+	const orig = `package svc
+
+import "context"
+
+// Ping pings.
+//
+//encore:api public
+func Ping(ctx context.Context) error {
+	return nil
+}
+
+type Params struct{ Name string }
+`
+	tests := []struct {
+		name    string
+		src     string
+		changed bool
+	}{
+		{
+			name: "body",
+			src: `package svc
+
+import "context"
+
+// Ping pings.
+//
+//encore:api public
+func Ping(ctx context.Context) error {
+	x := 1
+	_ = x
+	return nil
+}
+
+type Params struct{ Name string }
+`,
+			changed: false,
+		},
+		{
+			name: "comment",
+			src: `package svc
+
+import "context"
+
+// Ping pings the service.
+//
+//encore:api public
+func Ping(ctx context.Context) error {
+	return nil
+}
+
+type Params struct{ Name string }
+`,
+			changed: false,
+		},
+		{
+			name: "directive",
+			src: `package svc
+
+import "context"
+
+// Ping pings.
+//
+//encore:api private
+func Ping(ctx context.Context) error {
+	return nil
+}
+
+type Params struct{ Name string }
+`,
+			changed: true,
+		},
+		{
+			name: "signature",
+			src: `package svc
+
+import "context"
+
+// Ping pings.
+//
+//encore:api public
+func Ping(ctx context.Context, p *Params) error {
+	return nil
+}
+
+type Params struct{ Name string }
+`,
+			changed: true,
+		},
+		{
+			name: "type",
+			src: `package svc
+
+import "context"
+
+// Ping pings.
+//
+//encore:api public
+func Ping(ctx context.Context) error {
+	return nil
+}
+
+type Params struct{ Name, Email string }
+`,
+			changed: true,
+		},
+	}
+
+	shape := func(c *qt.C, src string) string {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "svc.go", src, parser.ParseComments)
+		c.Assert(err, qt.IsNil)
+		return packageShape(fset, []*ast.File{f})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(shape(c, tt.src) != shape(c, orig), qt.Equals, tt.changed)
+		})
+	}
+}
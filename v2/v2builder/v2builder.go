@@ -20,12 +20,14 @@ import (
 	"encr.dev/internal/env"
 	"encr.dev/internal/etrace"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
 	"encr.dev/pkg/vfs"
+	meta "encr.dev/proto/afterpiece/parser/meta/v1"
 	"encr.dev/v2/app"
 	"encr.dev/v2/app/legacymeta"
 	"encr.dev/v2/codegen"
@@ -92,6 +94,11 @@ func (*BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.
 
 		if appFile, err := p.App.AppFile(); err == nil {
 			pc.Build.MigrationStrategy = appFile.Migrations.Strategy
+			casing, err := nameCasing(appFile.API.QueryStringCasing)
+			if err != nil {
+				return nil, err
+			}
+			pc.Build.QueryStringCasing = casing
 		}
 
 		parser := parser.NewParser(pc)
@@ -101,8 +108,16 @@ func (*BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.
 		mainModule := parser.MainModule()
 		runtimeModule := parser.RuntimeModule()
 
-		if pc.Errs.Len() > 0 {
-			return nil, pc.Errs.AsError()
+		// scopeDirs limits the reported errors, if the check is scoped.
+		var scopeDirs map[string]bool
+		if len(p.Packages) > 0 {
+			scopeDirs = packageDirs(parserResult.AppPackages(), p.Packages)
+		} else if p.SingleFile != "" {
+			scopeDirs = fileDirs(parserResult.AppPackages(), p.SingleFile)
+		}
+
+		if err := scopedError(pc.Errs, scopeDirs); err != nil {
+			return nil, err
 		}
 
 		return &builder.ParseResult{
@@ -113,6 +128,7 @@ func (*BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.
 				mainModule:    mainModule,
 				runtimeModule: runtimeModule,
 				traceNodes:    traceNodes,
+				scopeDirs:     scopeDirs,
 			},
 		}, nil
 	})
@@ -124,6 +140,10 @@ type parseData struct {
 	mainModule    *pkginfo.Module
 	runtimeModule *pkginfo.Module
 	traceNodes    *legacymeta.TraceNodes
+
+	// scopeDirs, if non-nil, are the directories of the packages
+	// the compile errors are reported for.
+	scopeDirs map[string]bool
 }
 
 func (*BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*builder.CompileResult, error) {
@@ -149,9 +169,9 @@ func (*BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*buil
 			ExecScriptMainPkg: p.Build.MainPkg,
 		})
 
-		if pd.pc.Errs.Len() > 0 {
-			p.OpTracker.Fail(codegenOp, pd.pc.Errs.AsError())
-			return res, pd.pc.Errs.AsError()
+		if err := scopedError(pd.pc.Errs, pd.scopeDirs); err != nil {
+			p.OpTracker.Fail(codegenOp, err)
+			return res, err
 		}
 		p.OpTracker.Done(codegenOp, 450*time.Millisecond)
 
@@ -201,9 +221,9 @@ func (*BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*buil
 		}}
 
 		// Check if the compile result caused errors and if it did return
-		if pd.pc.Errs.Len() > 0 {
-			p.OpTracker.Fail(compileOp, pd.pc.Errs.AsError())
-			return res, pd.pc.Errs.AsError()
+		if err := scopedError(pd.pc.Errs, pd.scopeDirs); err != nil {
+			p.OpTracker.Fail(compileOp, err)
+			return res, err
 		}
 		p.OpTracker.Done(compileOp, 450*time.Millisecond)
 
@@ -511,3 +531,20 @@ func (i *BuilderImpl) writeOrDeleteFile(errs *perr.List, data []byte, dst paths.
 		}
 	}
 }
+
+// nameCasing parses a casing convention from the app file.
+func nameCasing(s string) (meta.NameCasing, error) {
+	switch s {
+	case "", appfile.NameCasingSnake:
+		return meta.NameCasing_SNAKE_CASE, nil
+	case appfile.NameCasingCamel:
+		return meta.NameCasing_CAMEL_CASE, nil
+	case appfile.NameCasingKebab:
+		return meta.NameCasing_KEBAB_CASE, nil
+	case appfile.NameCasingAsIs:
+		return meta.NameCasing_AS_IS, nil
+	default:
+		return 0, errors.Newf("invalid api.query_string_casing %q: must be one of %q, %q, %q or %q",
+			s, appfile.NameCasingSnake, appfile.NameCasingCamel, appfile.NameCasingKebab, appfile.NameCasingAsIs)
+	}
+}